@@ -0,0 +1,214 @@
+package functions
+
+import (
+	"fmt"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+)
+
+type Aggregate interface {
+	Step(arguments ...types.Value) error
+	Result() (types.Value, error)
+}
+
+type aggregate_function struct {
+	min_arguments int
+	max_arguments int
+	create        func() Aggregate
+}
+
+var aggregates = map[string]aggregate_function{
+	"avg":   {1, 1, func() Aggregate { return &average{} }},
+	"count": {1, 1, func() Aggregate { return &count{} }},
+	"max":   {1, 1, func() Aggregate { return &extreme{"max", 1, types.NewNull()} }},
+	"min":   {1, 1, func() Aggregate { return &extreme{"min", -1, types.NewNull()} }},
+	"sum":   {1, 1, func() Aggregate { return &sum{} }},
+}
+
+func IsAggregate(name string) bool {
+	_, ok := lookup_aggregate(name)
+	return ok
+}
+
+func lookup_aggregate(name string) (aggregate_function, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	function, ok := aggregates[name]
+	return function, ok
+}
+
+func NewAggregate(name string, distinct bool) (Aggregate, error) {
+	function, ok := lookup_aggregate(name)
+	if !ok {
+		return nil, fmt.Errorf("Unknown aggregate function %s", name)
+	}
+
+	if distinct {
+		return &distinct_aggregate{function.create(), make(map[string]bool)}, nil
+	}
+
+	return function.create(), nil
+}
+
+type count struct {
+	rows int64
+}
+
+func (aggregate *count) Step(arguments ...types.Value) error {
+	if len(arguments) == 0 || !arguments[0].IsNull() {
+		aggregate.rows += 1
+	}
+
+	return nil
+}
+
+func (aggregate *count) Result() (types.Value, error) {
+	return types.NewInteger(aggregate.rows), nil
+}
+
+type sum struct {
+	total types.Value
+}
+
+func (aggregate *sum) Step(arguments ...types.Value) error {
+	value := arguments[0]
+	if value.IsNull() {
+		return nil
+	}
+
+	if !value.Type().IsNumeric() {
+		return fmt.Errorf("Function sum does not accept %s", value.Type())
+	}
+
+	if aggregate.total.IsNull() {
+		aggregate.total = value
+		return nil
+	}
+
+	total, err := types.Add(aggregate.total, value)
+	if err != nil {
+		return err
+	}
+
+	aggregate.total = total
+	return nil
+}
+
+func (aggregate *sum) Result() (types.Value, error) {
+	return aggregate.total, nil
+}
+
+type average struct {
+	sum
+	rows int64
+}
+
+func (aggregate *average) Step(arguments ...types.Value) error {
+	value := arguments[0]
+	if value.IsNull() {
+		return nil
+	}
+
+	if !value.Type().IsNumeric() {
+		return fmt.Errorf("Function avg does not accept %s", value.Type())
+	}
+
+	if value.Type() == types.TYPE_INTEGER {
+		value = types.NewDecimal(types.MakeDecimal(value.Integer(), 0))
+	}
+
+	aggregate.rows += 1
+	return aggregate.sum.Step(value)
+}
+
+func (aggregate *average) Result() (types.Value, error) {
+	if aggregate.rows == 0 {
+		return types.NewNull(), nil
+	}
+
+	return types.Divide(aggregate.total, types.NewInteger(aggregate.rows))
+}
+
+type extreme struct {
+	name   string
+	sign   int
+	result types.Value
+}
+
+func (aggregate *extreme) Step(arguments ...types.Value) error {
+	value := arguments[0]
+	if value.IsNull() {
+		return nil
+	}
+
+	if aggregate.result.IsNull() {
+		aggregate.result = value
+		return nil
+	}
+
+	result, err := types.Compare(value, aggregate.result)
+	if err != nil {
+		return fmt.Errorf("Function %s: %w", aggregate.name, err)
+	}
+
+	if result*aggregate.sign > 0 {
+		aggregate.result = value
+	}
+
+	return nil
+}
+
+func (aggregate *extreme) Result() (types.Value, error) {
+	return aggregate.result, nil
+}
+
+// Exact numerics that compare equal must share a key, so 1, 1.0 and 1.00 count once.
+func distinct_value(value types.Value) types.Value {
+	switch value.Type() {
+	case types.TYPE_INTEGER:
+		return types.NewDecimal(types.MakeDecimal(value.Integer(), 0))
+	case types.TYPE_DECIMAL:
+		return types.NewDecimal(value.Decimal().Normalize())
+	default:
+		return value
+	}
+}
+
+type distinct_aggregate struct {
+	aggregate Aggregate
+	seen      map[string]bool
+}
+
+func (aggregate *distinct_aggregate) Step(arguments ...types.Value) error {
+	if has_null(arguments) {
+		return nil
+	}
+
+	var key []byte
+	for _, argument := range arguments {
+		argument = distinct_value(argument)
+		key = types.Encode(append(key, byte(argument.Type())), argument)
+	}
+
+	if aggregate.seen[string(key)] {
+		return nil
+	}
+
+	aggregate.seen[string(key)] = true
+	return aggregate.aggregate.Step(arguments...)
+}
+
+func has_null(arguments []types.Value) bool {
+	for _, argument := range arguments {
+		if argument.IsNull() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (aggregate *distinct_aggregate) Result() (types.Value, error) {
+	return aggregate.aggregate.Result()
+}
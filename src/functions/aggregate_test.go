@@ -0,0 +1,96 @@
+package functions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func RunAggregate(t *testing.T, name string, distinct bool, values ...types.Value) (types.Value, error) {
+	aggregate, err := NewAggregate(name, distinct)
+	assert.NoError(t, err)
+
+	for _, value := range values {
+		if err := aggregate.Step(value); err != nil {
+			return types.Value{}, err
+		}
+	}
+
+	return aggregate.Result()
+}
+
+func TestAggregates(t *testing.T) {
+	values := []types.Value{types.NewInteger(3), types.NewNull(), types.NewInteger(1), types.NewInteger(3)}
+
+	result, err := RunAggregate(t, "count", false, values...)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(3), result)
+
+	result, err = RunAggregate(t, "count", true, values...)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(2), result)
+
+	result, err = RunAggregate(t, "sum", false, values...)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(7), result)
+
+	result, err = RunAggregate(t, "sum", true, values...)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(4), result)
+
+	result, err = RunAggregate(t, "avg", false, values...)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.333333", result.String())
+
+	result, err = RunAggregate(t, "min", false, values...)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(1), result)
+
+	result, err = RunAggregate(t, "max", false, types.NewText("b"), types.NewText("c"), types.NewText("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewText("c"), result)
+}
+
+func TestDistinctAggregateNumerics(t *testing.T) {
+	result, err := RunAggregate(t, "count", true, types.NewInteger(1), decimal("1.0"), decimal("1.00"), decimal("-0.0"), types.NewInteger(0))
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(2), result)
+
+	result, err = RunAggregate(t, "sum", true, decimal("1.5"), decimal("1.50"), types.NewInteger(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "3.5", result.String())
+}
+
+func TestAggregatesEmpty(t *testing.T) {
+	result, err := RunAggregate(t, "count", false)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(0), result)
+
+	for _, name := range []string{"sum", "avg", "min", "max"} {
+		result, err := RunAggregate(t, name, false, types.NewNull())
+		assert.NoError(t, err)
+		assert.True(t, result.IsNull(), name)
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	_, err := NewAggregate("median", false)
+	assert.EqualError(t, err, "Unknown aggregate function median")
+
+	assert.NoError(t, CheckArguments("count", 1))
+	assert.EqualError(t, CheckArguments("sum", 2), "Function sum takes exactly one argument")
+
+	_, err = RunAggregate(t, "sum", false, types.NewText("a"))
+	assert.EqualError(t, err, "Function sum does not accept TEXT")
+
+	_, err = RunAggregate(t, "sum", false, types.NewInteger(math.MaxInt64), types.NewInteger(1))
+	assert.Equal(t, types.ErrIntegerOutOfRange, err)
+
+	_, err = RunAggregate(t, "max", false, types.NewInteger(1), types.NewText("a"))
+	assert.EqualError(t, err, "Function max: Cannot compare TEXT with INTEGER")
+
+	assert.True(t, IsAggregate("count"))
+	assert.False(t, IsAggregate("lower"))
+}
@@ -0,0 +1,380 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+)
+
+const MICROSECONDS_PER_JULIAN_YEAR = 365*types.MICROSECONDS_PER_DAY + types.MICROSECONDS_PER_DAY/4
+
+var clock = time.Now
+
+var date_fields = map[string]bool{
+	"microsecond": true, "millisecond": true, "second": true, "minute": true, "hour": true,
+	"day": true, "dow": true, "isodow": true, "doy": true, "week": true, "month": true, "quarter": true,
+	"year": true, "isoyear": true, "decade": true, "century": true, "millennium": true, "epoch": true,
+}
+
+var strptime_layouts = map[rune]string{
+	'a': "Mon", 'A': "Monday", 'b': "Jan", 'B': "January", 'd': "02", 'e': "_2", 'F': "2006-01-02",
+	'H': "15", 'I': "03", 'j': "002", 'm': "01", 'M': "04", 'p': "PM", 'S': "05", 'T': "15:04:05",
+	'y': "06", 'Y': "2006", 'z': "-0700", '%': "%",
+}
+
+func IsDateField(field string) bool {
+	return date_fields[normalise_field(field)]
+}
+
+func normalise_field(field string) string {
+	return strings.TrimSuffix(strings.ToLower(field), "s")
+}
+
+func now(_ string, _ []types.Value) (types.Value, error) {
+	return types.NewTimestamp(clock().UnixMicro()), nil
+}
+
+func current_date(name string, arguments []types.Value) (types.Value, error) {
+	timestamp, _ := now(name, arguments)
+	return types.Cast(timestamp, types.TYPE_DATE)
+}
+
+func current_time(name string, arguments []types.Value) (types.Value, error) {
+	timestamp, _ := now(name, arguments)
+	return types.Cast(timestamp, types.TYPE_TIME)
+}
+
+func date_part(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments[:1])
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	field, source := normalise_field(texts[0]), arguments[1]
+
+	var result types.Value
+	var ok bool
+	switch source.Type() {
+	case types.TYPE_DATE, types.TYPE_TIMESTAMP:
+		result, ok = timestamp_part(field, source)
+	case types.TYPE_TIME:
+		result, ok = clock_part(field, source.Integer())
+	case types.TYPE_INTERVAL:
+		result, ok = interval_part(field, source)
+	default:
+		return types.Value{}, argument_error(name, source)
+	}
+
+	if !ok {
+		return types.Value{}, unit_error(texts[0], source)
+	}
+
+	return result, nil
+}
+
+func timestamp_part(field string, source types.Value) (types.Value, bool) {
+	timestamp, err := types.Cast(source, types.TYPE_TIMESTAMP)
+	if err != nil {
+		return types.Value{}, false
+	}
+
+	moment := timestamp.Time()
+	year := int64(moment.Year())
+
+	switch field {
+	case "day":
+		return types.NewInteger(int64(moment.Day())), true
+	case "dow":
+		return types.NewInteger(int64(moment.Weekday())), true
+	case "isodow":
+		return types.NewInteger(int64(iso_weekday(moment))), true
+	case "doy":
+		return types.NewInteger(int64(moment.YearDay())), true
+	case "week":
+		_, week := moment.ISOWeek()
+		return types.NewInteger(int64(week)), true
+	case "month":
+		return types.NewInteger(int64(moment.Month())), true
+	case "quarter":
+		return types.NewInteger(int64(moment.Month()-1)/3 + 1), true
+	case "year":
+		return types.NewInteger(year), true
+	case "isoyear":
+		year, _ := moment.ISOWeek()
+		return types.NewInteger(int64(year)), true
+	case "decade":
+		return types.NewInteger(year / 10), true
+	case "century":
+		return types.NewInteger((year + 99) / 100), true
+	case "millennium":
+		return types.NewInteger((year + 999) / 1000), true
+	case "epoch":
+		return types.NewDecimal(types.MakeDecimal(timestamp.Integer(), 6)), true
+	}
+
+	day := timestamp.Integer() % types.MICROSECONDS_PER_DAY
+	if day < 0 {
+		day += types.MICROSECONDS_PER_DAY
+	}
+
+	return clock_part(field, day)
+}
+
+func clock_part(field string, microseconds int64) (types.Value, bool) {
+	seconds := microseconds % types.MICROSECONDS_PER_MINUTE
+
+	switch field {
+	case "hour":
+		return types.NewInteger(microseconds / types.MICROSECONDS_PER_HOUR), true
+	case "minute":
+		return types.NewInteger(microseconds % types.MICROSECONDS_PER_HOUR / types.MICROSECONDS_PER_MINUTE), true
+	case "second":
+		return types.NewDecimal(types.MakeDecimal(seconds, 6)), true
+	case "millisecond":
+		return types.NewDecimal(types.MakeDecimal(seconds, 3)), true
+	case "microsecond":
+		return types.NewInteger(seconds), true
+	case "epoch":
+		return types.NewDecimal(types.MakeDecimal(microseconds, 6)), true
+	}
+
+	return types.Value{}, false
+}
+
+func interval_part(field string, source types.Value) (types.Value, bool) {
+	months, microseconds := source.Months(), source.Integer()
+
+	switch field {
+	case "year":
+		return types.NewInteger(months / types.MONTHS_PER_YEAR), true
+	case "month":
+		return types.NewInteger(months % types.MONTHS_PER_YEAR), true
+	case "day":
+		return types.NewInteger(microseconds / types.MICROSECONDS_PER_DAY), true
+	case "epoch":
+		years, months := months/types.MONTHS_PER_YEAR, months%types.MONTHS_PER_YEAR
+		epoch := types.MakeDecimal(years, 0).Multiply(types.MakeDecimal(MICROSECONDS_PER_JULIAN_YEAR, 6))
+		epoch = epoch.Add(types.MakeDecimal(months*types.DAYS_PER_MONTH*types.MICROSECONDS_PER_DAY, 6))
+		return types.NewDecimal(epoch.Add(types.MakeDecimal(microseconds, 6))), true
+	}
+
+	return clock_part(field, microseconds%types.MICROSECONDS_PER_DAY)
+}
+
+func date_trunc(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments[:1])
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	source := arguments[1]
+	if source.Type() != types.TYPE_DATE && source.Type() != types.TYPE_TIMESTAMP {
+		return types.Value{}, argument_error(name, source)
+	}
+
+	timestamp, err := types.Cast(source, types.TYPE_TIMESTAMP)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	moment := timestamp.Time()
+	year, month, day := moment.Date()
+	hour, minute, second := moment.Clock()
+	nanosecond := moment.Nanosecond()
+
+	switch field := normalise_field(texts[0]); field {
+	case "microsecond":
+		return timestamp, nil
+	case "millisecond":
+		nanosecond -= nanosecond % int(time.Millisecond)
+	case "second":
+		nanosecond = 0
+	case "minute":
+		second, nanosecond = 0, 0
+	case "hour":
+		minute, second, nanosecond = 0, 0, 0
+	case "day":
+		hour, minute, second, nanosecond = 0, 0, 0, 0
+	case "week":
+		day -= iso_weekday(moment) - 1
+		hour, minute, second, nanosecond = 0, 0, 0, 0
+	case "month":
+		day, hour, minute, second, nanosecond = 1, 0, 0, 0, 0
+	case "quarter":
+		month = (month-1)/3*3 + 1
+		day, hour, minute, second, nanosecond = 1, 0, 0, 0, 0
+	case "year", "decade", "century", "millennium":
+		switch field {
+		case "decade":
+			year -= year % 10
+		case "century":
+			year -= (year - 1) % 100
+		case "millennium":
+			year -= (year - 1) % 1000
+		}
+		month, day, hour, minute, second, nanosecond = 1, 1, 0, 0, 0, 0
+	default:
+		return types.Value{}, unit_error(texts[0], source)
+	}
+
+	return types.NewTimestamp(time.Date(year, month, day, hour, minute, second, nanosecond, time.UTC).UnixMicro()), nil
+}
+
+func strftime(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments[:1])
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	source := arguments[1]
+	if !source.Type().IsTemporal() {
+		return types.Value{}, argument_error(name, source)
+	}
+
+	moment := source.Time()
+	hour12 := moment.Hour() % 12
+	if hour12 == 0 {
+		hour12 = 12
+	}
+
+	var result strings.Builder
+	runes := []rune(texts[0])
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			result.WriteRune(runes[i])
+			continue
+		}
+
+		i += 1
+		if i == len(runes) {
+			return types.Value{}, errors.New("Format string must not end with '%'")
+		}
+
+		switch directive := runes[i]; directive {
+		case 'a', 'A', 'b', 'B', 'p':
+			result.WriteString(moment.Format(strptime_layouts[directive]))
+		case 'd':
+			fmt.Fprintf(&result, "%02d", moment.Day())
+		case 'e':
+			fmt.Fprintf(&result, "%2d", moment.Day())
+		case 'f':
+			fmt.Fprintf(&result, "%06d", moment.Nanosecond()/1000)
+		case 'F':
+			fmt.Fprintf(&result, "%04d-%02d-%02d", moment.Year(), moment.Month(), moment.Day())
+		case 'H':
+			fmt.Fprintf(&result, "%02d", moment.Hour())
+		case 'I':
+			fmt.Fprintf(&result, "%02d", hour12)
+		case 'j':
+			fmt.Fprintf(&result, "%03d", moment.YearDay())
+		case 'm':
+			fmt.Fprintf(&result, "%02d", moment.Month())
+		case 'M':
+			fmt.Fprintf(&result, "%02d", moment.Minute())
+		case 's':
+			result.WriteString(strconv.FormatInt(moment.Unix(), 10))
+		case 'S':
+			fmt.Fprintf(&result, "%02d", moment.Second())
+		case 'T':
+			fmt.Fprintf(&result, "%02d:%02d:%02d", moment.Hour(), moment.Minute(), moment.Second())
+		case 'u':
+			fmt.Fprintf(&result, "%d", iso_weekday(moment))
+		case 'w':
+			fmt.Fprintf(&result, "%d", moment.Weekday())
+		case 'y':
+			fmt.Fprintf(&result, "%02d", moment.Year()%100)
+		case 'Y':
+			fmt.Fprintf(&result, "%04d", moment.Year())
+		case '%':
+			result.WriteRune('%')
+		default:
+			return types.Value{}, directive_error(directive)
+		}
+	}
+
+	return types.NewText(result.String()), nil
+}
+
+func strptime(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	var layout strings.Builder
+	runes := []rune(texts[1])
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			layout.WriteRune(runes[i])
+			continue
+		}
+
+		i += 1
+		if i == len(runes) {
+			return types.Value{}, errors.New("Format string must not end with '%'")
+		}
+
+		directive := runes[i]
+		switch element, ok := strptime_layouts[directive]; {
+		case directive == 'f' && strings.HasSuffix(layout.String(), "."):
+			layout.WriteString("999999")
+		case ok:
+			layout.WriteString(element)
+		default:
+			return types.Value{}, directive_error(directive)
+		}
+	}
+
+	parsed, err := time.Parse(layout.String(), texts[0])
+	if err != nil {
+		return types.Value{}, fmt.Errorf("Value '%s' does not match format '%s'", texts[0], texts[1])
+	}
+
+	return types.NewTimestamp(parsed.UnixMicro()), nil
+}
+
+func timezone(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments[:1])
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	source := arguments[1]
+	if source.Type() != types.TYPE_DATE && source.Type() != types.TYPE_TIMESTAMP {
+		return types.Value{}, argument_error(name, source)
+	}
+
+	location, err := time.LoadLocation(texts[0])
+	if err != nil || texts[0] == "" || texts[0] == "Local" {
+		return types.Value{}, fmt.Errorf("Time zone '%s' not recognized", texts[0])
+	}
+
+	timestamp, err := types.Cast(source, types.TYPE_TIMESTAMP)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	_, offset := timestamp.Time().In(location).Zone()
+	return types.Add(timestamp, types.NewInterval(0, int64(offset)*types.MICROSECONDS_PER_SECOND))
+}
+
+func iso_weekday(moment time.Time) int {
+	if moment.Weekday() == time.Sunday {
+		return 7
+	}
+
+	return int(moment.Weekday())
+}
+
+func unit_error(field string, source types.Value) error {
+	return fmt.Errorf("Unit '%s' not recognized for %s", field, source.Type())
+}
+
+func directive_error(directive rune) error {
+	return fmt.Errorf("Unsupported format directive '%%%c'", directive)
+}
@@ -0,0 +1,124 @@
+package functions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func timestamp(text string) types.Value {
+	value, _ := types.ParseTimestamp(text)
+	return value
+}
+
+func interval(text string) types.Value {
+	value, _ := types.ParseInterval(text)
+	return value
+}
+
+func TestDateTimeFunctions(t *testing.T) {
+	text := types.NewText
+	moment := timestamp("2024-03-10 14:05:06.25")
+	date, _ := types.ParseDate("2024-03-10")
+	clock_time, _ := types.ParseTime("08:30:15.5")
+
+	cases := []struct {
+		name      string
+		arguments []types.Value
+		expected  string
+	}{
+		{"date_part", []types.Value{text("year"), moment}, "2024"},
+		{"date_part", []types.Value{text("QUARTER"), moment}, "1"},
+		{"date_part", []types.Value{text("dow"), moment}, "0"},
+		{"date_part", []types.Value{text("isodow"), moment}, "7"},
+		{"date_part", []types.Value{text("doy"), moment}, "70"},
+		{"date_part", []types.Value{text("week"), moment}, "10"},
+		{"date_part", []types.Value{text("hours"), moment}, "14"},
+		{"date_part", []types.Value{text("second"), moment}, "6.250000"},
+		{"date_part", []types.Value{text("milliseconds"), moment}, "6250.000"},
+		{"date_part", []types.Value{text("century"), moment}, "21"},
+		{"date_part", []types.Value{text("epoch"), timestamp("1970-01-02 00:00:01")}, "86401.000000"},
+		{"date_part", []types.Value{text("month"), date}, "3"},
+		{"date_part", []types.Value{text("minute"), clock_time}, "30"},
+		{"extract", []types.Value{text("day"), interval("1 year 2 months 3 days 04:00")}, "3"},
+		{"extract", []types.Value{text("month"), interval("1 year 2 months")}, "2"},
+		{"extract", []types.Value{text("epoch"), interval("1 year 1 day")}, "31644000.000000"},
+		{"date_trunc", []types.Value{text("hour"), moment}, "2024-03-10 14:00:00"},
+		{"date_trunc", []types.Value{text("week"), moment}, "2024-03-04 00:00:00"},
+		{"date_trunc", []types.Value{text("quarter"), moment}, "2024-01-01 00:00:00"},
+		{"date_trunc", []types.Value{text("century"), moment}, "2001-01-01 00:00:00"},
+		{"date_trunc", []types.Value{text("millisecond"), moment}, "2024-03-10 14:05:06.25"},
+		{"date_trunc", []types.Value{text("month"), date}, "2024-03-01 00:00:00"},
+		{"strftime", []types.Value{text("%Y-%m-%d %H:%M:%S.%f"), moment}, "2024-03-10 14:05:06.250000"},
+		{"strftime", []types.Value{text("%a %d %b %Y, %I:%M %p (day %j, %%)"), moment}, "Sun 10 Mar 2024, 02:05 PM (day 070, %)"},
+		{"strftime", []types.Value{text("%s %u %w %e"), timestamp("1970-01-02")}, "86400 5 5  2"},
+		{"strftime", []types.Value{text("%T"), clock_time}, "08:30:15"},
+		{"strptime", []types.Value{text("10/03/2024 14:05:06.25"), text("%d/%m/%Y %H:%M:%S.%f")}, "2024-03-10 14:05:06.25"},
+		{"strptime", []types.Value{text("March 10 2024 02PM +0100"), text("%B %d %Y %I%p %z")}, "2024-03-10 13:00:00"},
+		{"strptime", []types.Value{text("2024-070"), text("%Y-%j")}, "2024-03-10 00:00:00"},
+		{"timezone", []types.Value{text("America/New_York"), moment}, "2024-03-10 10:05:06.25"},
+		{"timezone", []types.Value{text("America/New_York"), timestamp("2024-03-10 06:00:00")}, "2024-03-10 01:00:00"},
+		{"timezone", []types.Value{text("Asia/Kolkata"), date}, "2024-03-10 05:30:00"},
+		{"date_trunc", []types.Value{text("day"), types.NewNull()}, "NULL"},
+	}
+
+	for _, test := range cases {
+		result, err := CallScalar(test.name, test.arguments)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, result.String(), test.name)
+	}
+}
+
+func TestCurrentTimestamp(t *testing.T) {
+	clock = func() time.Time { return time.Date(2024, 3, 10, 14, 5, 6, 0, time.UTC) }
+	defer func() { clock = time.Now }()
+
+	for name, expected := range map[string]string{
+		"now":               "2024-03-10 14:05:06",
+		"current_timestamp": "2024-03-10 14:05:06",
+		"current_date":      "2024-03-10",
+		"current_time":      "14:05:06",
+	} {
+		result, err := CallScalar(name, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result.String(), name)
+	}
+
+	assert.EqualError(t, CheckArguments("now", 1), "Function now takes exactly 0 arguments")
+}
+
+func TestDateTimeErrors(t *testing.T) {
+	moment := timestamp("2024-03-10 14:05:06")
+
+	_, err := CallScalar("date_part", []types.Value{types.NewText("fortnight"), moment})
+	assert.EqualError(t, err, "Unit 'fortnight' not recognized for TIMESTAMP")
+
+	_, err = CallScalar("date_part", []types.Value{types.NewText("year"), types.NewTime(0)})
+	assert.EqualError(t, err, "Unit 'year' not recognized for TIME")
+
+	_, err = CallScalar("date_trunc", []types.Value{types.NewText("epoch"), moment})
+	assert.EqualError(t, err, "Unit 'epoch' not recognized for TIMESTAMP")
+
+	_, err = CallScalar("date_trunc", []types.Value{types.NewText("day"), types.NewText("2024-03-10")})
+	assert.EqualError(t, err, "Function date_trunc does not accept TEXT")
+
+	_, err = CallScalar("strftime", []types.Value{types.NewText("%Q"), moment})
+	assert.EqualError(t, err, "Unsupported format directive '%Q'")
+
+	_, err = CallScalar("strftime", []types.Value{types.NewText("100%"), moment})
+	assert.EqualError(t, err, "Format string must not end with '%'")
+
+	_, err = CallScalar("strptime", []types.Value{types.NewText("2024-13-01"), types.NewText("%Y-%m-%d")})
+	assert.EqualError(t, err, "Value '2024-13-01' does not match format '%Y-%m-%d'")
+
+	_, err = CallScalar("strptime", []types.Value{types.NewText("12"), types.NewText("%f")})
+	assert.EqualError(t, err, "Unsupported format directive '%f'")
+
+	_, err = CallScalar("timezone", []types.Value{types.NewText("Mars/Olympus_Mons"), moment})
+	assert.EqualError(t, err, "Time zone 'Mars/Olympus_Mons' not recognized")
+
+	assert.True(t, IsDateField("Years"))
+	assert.False(t, IsDateField("fortnight"))
+}
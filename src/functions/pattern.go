@@ -0,0 +1,169 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+)
+
+const LIKE_ESCAPE = "\\"
+
+func Like(value types.Value, pattern types.Value, escape types.Value, fold bool) (types.Value, error) {
+	operator := "LIKE"
+	if fold {
+		operator = "ILIKE"
+	}
+
+	if err := check_pattern_operands(operator, value, pattern); err != nil {
+		return types.Value{}, err
+	}
+
+	if !escape.IsNull() && escape.Type() != types.TYPE_TEXT {
+		return types.Value{}, fmt.Errorf("Cannot use %s as an escape string", escape.Type())
+	}
+
+	if value.IsNull() || pattern.IsNull() || escape.IsNull() {
+		return types.NewNull(), nil
+	}
+
+	expression, err := compile_like(pattern.Text(), escape.Text(), fold)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	return types.NewBoolean(expression.MatchString(value.Text())), nil
+}
+
+func Glob(value types.Value, pattern types.Value) (types.Value, error) {
+	if err := check_pattern_operands("GLOB", value, pattern); err != nil {
+		return types.Value{}, err
+	}
+
+	if value.IsNull() || pattern.IsNull() {
+		return types.NewNull(), nil
+	}
+
+	expression, err := compile_glob(pattern.Text())
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	return types.NewBoolean(expression.MatchString(value.Text())), nil
+}
+
+func Regexp(value types.Value, pattern types.Value) (types.Value, error) {
+	if err := check_pattern_operands("REGEXP", value, pattern); err != nil {
+		return types.Value{}, err
+	}
+
+	if value.IsNull() || pattern.IsNull() {
+		return types.NewNull(), nil
+	}
+
+	expression, err := regexp.Compile(pattern.Text())
+	if err != nil {
+		return types.Value{}, fmt.Errorf("Invalid regular expression: %s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+
+	return types.NewBoolean(expression.MatchString(value.Text())), nil
+}
+
+func check_pattern_operands(operator string, value types.Value, pattern types.Value) error {
+	if (!value.IsNull() && value.Type() != types.TYPE_TEXT) || (!pattern.IsNull() && pattern.Type() != types.TYPE_TEXT) {
+		return fmt.Errorf("Cannot apply %s to %s and %s", operator, value.Type(), pattern.Type())
+	}
+
+	return nil
+}
+
+func compile_like(pattern string, escape string, fold bool) (*regexp.Regexp, error) {
+	if utf8.RuneCountInString(escape) > 1 {
+		return nil, errors.New("Invalid escape string")
+	}
+
+	escape_rune, _ := utf8.DecodeRuneInString(escape)
+
+	var expression strings.Builder
+	expression.WriteString("(?s)")
+	if fold {
+		expression.WriteString("(?i)")
+	}
+	expression.WriteString("^")
+
+	escaped := false
+	for _, char := range pattern {
+		switch {
+		case escaped:
+			expression.WriteString(regexp.QuoteMeta(string(char)))
+			escaped = false
+		case escape != "" && char == escape_rune:
+			escaped = true
+		case char == '%':
+			expression.WriteString(".*")
+		case char == '_':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	if escaped {
+		return nil, errors.New("LIKE pattern must not end with escape character")
+	}
+
+	expression.WriteString("$")
+	return regexp.Compile(expression.String())
+}
+
+func compile_glob(pattern string) (*regexp.Regexp, error) {
+	var expression strings.Builder
+	expression.WriteString("(?s)^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch char := runes[i]; char {
+		case '*':
+			expression.WriteString(".*")
+		case '?':
+			expression.WriteString(".")
+		case '[':
+			end := i + 1
+			if end < len(runes) && runes[end] == '^' {
+				end += 1
+			}
+			if end < len(runes) && runes[end] == ']' {
+				end += 1
+			}
+			for end < len(runes) && runes[end] != ']' {
+				end += 1
+			}
+			if end == len(runes) {
+				return nil, errors.New("Unterminated '[' in GLOB pattern")
+			}
+
+			expression.WriteString("[")
+			class := runes[i+1 : end]
+			if len(class) > 0 && class[0] == '^' {
+				expression.WriteString("^")
+				class = class[1:]
+			}
+			for _, member := range class {
+				if member == '\\' || member == '[' || member == ']' || member == '^' {
+					expression.WriteRune('\\')
+				}
+				expression.WriteRune(member)
+			}
+			expression.WriteString("]")
+			i = end
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	expression.WriteString("$")
+	return regexp.Compile(expression.String())
+}
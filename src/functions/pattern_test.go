@@ -0,0 +1,98 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLike(t *testing.T) {
+	text, escape := types.NewText, types.NewText(LIKE_ESCAPE)
+
+	cases := []struct {
+		value, pattern string
+		fold, expected bool
+	}{
+		{"hello", "h%", false, true},
+		{"hello", "h_llo", false, true},
+		{"hello", "h_lo", false, false},
+		{"Hello", "h%", false, false},
+		{"Hello", "h%", true, true},
+		{"100%", "100\\%", false, true},
+		{"1000", "100\\%", false, false},
+		{"a.b", "a.b", false, true},
+		{"axb", "a.b", false, false},
+		{"line\nbreak", "line%", false, true},
+		{"", "%", false, true},
+	}
+
+	for _, test := range cases {
+		result, err := Like(text(test.value), text(test.pattern), escape, test.fold)
+		assert.NoError(t, err)
+		assert.Equal(t, types.NewBoolean(test.expected), result, test.value+" LIKE "+test.pattern)
+	}
+
+	result, err := Like(text("a+b"), text("a#+b"), text("#"), false)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewBoolean(true), result)
+
+	result, err = Like(text("a\\b"), text("a\\b"), text(""), false)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewBoolean(true), result)
+
+	result, err = Like(types.NewNull(), text("%"), escape, false)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewNull(), result)
+}
+
+func TestGlobAndRegexp(t *testing.T) {
+	text := types.NewText
+
+	cases := []struct {
+		value, pattern string
+		expected       bool
+	}{
+		{"file.go", "*.go", true},
+		{"file.GO", "*.go", false},
+		{"a1", "a?", true},
+		{"b", "[abc]", true},
+		{"d", "[^abc]", true},
+		{"]", "[]x]", true},
+		{"m", "[a-z]", true},
+		{"100%", "100%", true},
+	}
+
+	for _, test := range cases {
+		result, err := Glob(text(test.value), text(test.pattern))
+		assert.NoError(t, err)
+		assert.Equal(t, types.NewBoolean(test.expected), result, test.value+" GLOB "+test.pattern)
+	}
+
+	result, err := Regexp(text("order-1234"), text(`\d{4}$`))
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewBoolean(true), result)
+
+	result, err = Regexp(text("order"), text(`^\d`))
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewBoolean(false), result)
+}
+
+func TestPatternErrors(t *testing.T) {
+	text, escape := types.NewText, types.NewText(LIKE_ESCAPE)
+
+	_, err := Like(text("a"), text("a\\"), escape, false)
+	assert.EqualError(t, err, "LIKE pattern must not end with escape character")
+
+	_, err = Like(text("a"), text("a"), text("ab"), false)
+	assert.EqualError(t, err, "Invalid escape string")
+
+	_, err = Like(types.NewInteger(1), text("1"), escape, true)
+	assert.EqualError(t, err, "Cannot apply ILIKE to INTEGER and TEXT")
+
+	_, err = Glob(text("a"), text("[ab"))
+	assert.EqualError(t, err, "Unterminated '[' in GLOB pattern")
+
+	_, err = Regexp(text("a"), text("("))
+	assert.EqualError(t, err, "Invalid regular expression: missing closing ): `(`")
+}
@@ -0,0 +1,105 @@
+package functions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	lex "github.com/JamesErrington/tasiadb/src/lexer"
+	"github.com/JamesErrington/tasiadb/src/types"
+)
+
+var registry sync.RWMutex
+
+func RegisterScalar(name string, arguments int, deterministic bool, apply func(arguments []types.Value) (types.Value, error)) error {
+	min_arguments, err := check_registered_arity(name, arguments)
+	if err != nil {
+		return err
+	}
+
+	if apply == nil {
+		return fmt.Errorf("Function %s must have an implementation", name)
+	}
+
+	function := scalar{min_arguments, arguments, false, deterministic, func(_ string, values []types.Value) (types.Value, error) {
+		return apply(values)
+	}}
+
+	return register(name, func(name string) { scalars[name] = function })
+}
+
+// RegisterAggregate does not call step for rows where any argument is NULL, matching the built-in aggregates.
+func RegisterAggregate(name string, arguments int, step func(state any, arguments []types.Value) (any, error), final func(state any) (types.Value, error)) error {
+	min_arguments, err := check_registered_arity(name, arguments)
+	if err != nil {
+		return err
+	}
+
+	if step == nil || final == nil {
+		return fmt.Errorf("Function %s must have an implementation", name)
+	}
+
+	function := aggregate_function{min_arguments, arguments, func() Aggregate { return &user_aggregate{step: step, final: final} }}
+
+	return register(name, func(name string) { aggregates[name] = function })
+}
+
+func check_registered_arity(name string, arguments int) (int, error) {
+	switch {
+	case arguments < VARIADIC:
+		return 0, fmt.Errorf("Invalid argument count %d for function %s", arguments, name)
+	case arguments == VARIADIC:
+		return 0, nil
+	default:
+		return arguments, nil
+	}
+}
+
+func register(name string, add func(name string)) error {
+	token, _ := lex.NewLexer(name).NextToken()
+	if token.IsUnreserved() {
+		token = token.AsIdentifier()
+	}
+
+	if !token.IsTokenType(lex.TOKEN_IDENTIFIER) || token.Value() != strings.ToLower(name) {
+		return fmt.Errorf("Invalid function name: '%s'", name)
+	}
+
+	name = token.Value()
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	_, is_scalar := scalars[name]
+	_, is_aggregate := aggregates[name]
+	if is_scalar || is_aggregate || IsWindow(name) {
+		return fmt.Errorf("Function %s already exists", name)
+	}
+
+	add(name)
+	return nil
+}
+
+type user_aggregate struct {
+	step  func(state any, arguments []types.Value) (any, error)
+	final func(state any) (types.Value, error)
+	state any
+}
+
+func (aggregate *user_aggregate) Step(arguments ...types.Value) error {
+	if has_null(arguments) {
+		return nil
+	}
+
+	state, err := aggregate.step(aggregate.state, arguments)
+	if err != nil {
+		return err
+	}
+
+	aggregate.state = state
+	return nil
+}
+
+func (aggregate *user_aggregate) Result() (types.Value, error) {
+	return aggregate.final(aggregate.state)
+}
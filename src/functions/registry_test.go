@@ -0,0 +1,149 @@
+package functions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterScalar(t *testing.T) {
+	err := RegisterScalar("Reverse", 1, true, func(arguments []types.Value) (types.Value, error) {
+		if arguments[0].IsNull() {
+			return types.NewText("null"), nil
+		}
+
+		runes := []rune(arguments[0].Text())
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+
+		return types.NewText(string(runes)), nil
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, IsScalar("reverse"))
+	assert.True(t, IsDeterministic("reverse"))
+	assert.EqualError(t, CheckArguments("reverse", 2), "Function reverse takes exactly one argument")
+
+	result, err := CallScalar("reverse", []types.Value{types.NewText("héllo")})
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewText("olléh"), result)
+
+	result, err = CallScalar("reverse", []types.Value{types.NewNull()})
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewText("null"), result)
+
+	err = RegisterScalar("random_choice", VARIADIC, false, func(arguments []types.Value) (types.Value, error) {
+		return types.NewInteger(int64(len(arguments))), errors.New("no choices")
+	})
+	assert.NoError(t, err)
+	assert.False(t, IsDeterministic("random_choice"))
+	assert.False(t, IsDeterministic("now"))
+	assert.NoError(t, CheckArguments("random_choice", 0))
+
+	_, err = CallScalar("random_choice", nil)
+	assert.EqualError(t, err, "no choices")
+}
+
+func TestRegisterAggregate(t *testing.T) {
+	err := RegisterAggregate("product", 1, func(state any, arguments []types.Value) (any, error) {
+		value := arguments[0]
+		if state == nil {
+			return value, nil
+		}
+
+		return types.Multiply(state.(types.Value), value)
+	}, func(state any) (types.Value, error) {
+		if state == nil {
+			return types.NewNull(), nil
+		}
+
+		return state.(types.Value), nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, IsAggregate("product"))
+
+	aggregate, err := NewAggregate("product", false)
+	assert.NoError(t, err)
+	for _, value := range []int64{2, 3, 3} {
+		assert.NoError(t, aggregate.Step(types.NewInteger(value)))
+	}
+	assert.NoError(t, aggregate.Step(types.NewNull()))
+
+	result, err := aggregate.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(18), result)
+
+	aggregate, _ = NewAggregate("product", true)
+	for _, value := range []int64{2, 3, 3} {
+		assert.NoError(t, aggregate.Step(types.NewInteger(value)))
+	}
+
+	result, err = aggregate.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(6), result)
+
+	partition := scores(false, 1, 2, 3)
+	assert.Equal(t, []string{"1", "2", "6"}, results(t, partition, "product", DefaultFrame))
+
+	aggregate, _ = NewAggregate("product", false)
+	assert.NoError(t, aggregate.Step(types.NewInteger(2)))
+	assert.EqualError(t, aggregate.Step(types.NewText("x")), "Cannot apply '*' to INTEGER and TEXT")
+
+	err = RegisterAggregate("weighted_sum", 2, func(state any, arguments []types.Value) (any, error) {
+		product, err := types.Multiply(arguments[0], arguments[1])
+		if err != nil || state == nil {
+			return product, err
+		}
+
+		return types.Add(state.(types.Value), product)
+	}, func(state any) (types.Value, error) {
+		if state == nil {
+			return types.NewNull(), nil
+		}
+
+		return state.(types.Value), nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, CheckArguments("weighted_sum", 2))
+	assert.EqualError(t, CheckArguments("weighted_sum", 1), "Function weighted_sum takes exactly 2 arguments")
+
+	aggregate, _ = NewAggregate("weighted_sum", false)
+	assert.NoError(t, aggregate.Step(types.NewInteger(2), types.NewInteger(3)))
+	assert.NoError(t, aggregate.Step(types.NewInteger(4), types.NewNull()))
+	assert.NoError(t, aggregate.Step(types.NewInteger(1), types.NewInteger(5)))
+
+	result, err = aggregate.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(11), result)
+
+	aggregate, _ = NewAggregate("weighted_sum", true)
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, aggregate.Step(types.NewInteger(2), types.NewInteger(3)))
+		assert.NoError(t, aggregate.Step(types.NewInteger(3), types.NewInteger(2)))
+	}
+
+	result, err = aggregate.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(12), result)
+}
+
+func TestRegisterErrors(t *testing.T) {
+	identity := func(arguments []types.Value) (types.Value, error) { return arguments[0], nil }
+	step := func(state any, _ []types.Value) (any, error) { return state, nil }
+	final := func(any) (types.Value, error) { return types.NewNull(), nil }
+
+	assert.EqualError(t, RegisterScalar("upper", 1, true, identity), "Function upper already exists")
+	assert.EqualError(t, RegisterScalar("SUM", 1, true, identity), "Function sum already exists")
+	assert.EqualError(t, RegisterAggregate("rank", 1, step, final), "Function rank already exists")
+	assert.EqualError(t, RegisterScalar("select", 1, true, identity), "Invalid function name: 'select'")
+	assert.EqualError(t, RegisterScalar("two words", 1, true, identity), "Invalid function name: 'two words'")
+	assert.EqualError(t, RegisterScalar("1st", 1, true, identity), "Invalid function name: '1st'")
+	assert.EqualError(t, RegisterScalar("identity", -2, true, identity), "Invalid argument count -2 for function identity")
+	assert.EqualError(t, RegisterScalar("identity", 1, true, nil), "Function identity must have an implementation")
+	assert.EqualError(t, RegisterAggregate("identity", 1, step, nil), "Function identity must have an implementation")
+	assert.EqualError(t, RegisterAggregate("identity", -3, step, final), "Invalid argument count -3 for function identity")
+	assert.False(t, IsScalar("identity"))
+}
@@ -0,0 +1,419 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+)
+
+const VARIADIC = -1
+
+type scalar struct {
+	min_arguments int
+	max_arguments int
+	strict        bool
+	deterministic bool
+	apply         func(name string, arguments []types.Value) (types.Value, error)
+}
+
+var scalars = map[string]scalar{
+	"abs":               {1, 1, true, true, abs},
+	"ceil":              {1, 1, true, true, ceil},
+	"ceiling":           {1, 1, true, true, ceil},
+	"coalesce":          {1, VARIADIC, false, true, coalesce},
+	"current_date":      {0, 0, false, false, current_date},
+	"current_time":      {0, 0, false, false, current_time},
+	"current_timestamp": {0, 0, false, false, now},
+	"date_part":         {2, 2, true, true, date_part},
+	"date_trunc":        {2, 2, true, true, date_trunc},
+	"extract":           {2, 2, true, true, date_part},
+	"floor":             {1, 1, true, true, floor},
+	"ifnull":            {2, 2, false, true, coalesce},
+	"instr":             {2, 2, true, true, instr},
+	"length":            {1, 1, true, true, length},
+	"lower":             {1, 1, true, true, lower},
+	"mod":               {2, 2, true, true, mod},
+	"now":               {0, 0, false, false, now},
+	"nullif":            {2, 2, false, true, nullif},
+	"power":             {2, 2, true, true, power},
+	"replace":           {3, 3, true, true, replace},
+	"round":             {1, 2, true, true, round},
+	"strftime":          {2, 2, true, true, strftime},
+	"strptime":          {2, 2, true, true, strptime},
+	"substr":            {2, 3, true, true, substr},
+	"timezone":          {2, 2, true, true, timezone},
+	"trim":              {1, 2, true, true, trim},
+	"upper":             {1, 1, true, true, upper},
+}
+
+func IsScalar(name string) bool {
+	_, ok := lookup_scalar(name)
+	return ok
+}
+
+func IsDeterministic(name string) bool {
+	function, ok := lookup_scalar(name)
+	return !ok || function.deterministic
+}
+
+func lookup_scalar(name string) (scalar, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	function, ok := scalars[name]
+	return function, ok
+}
+
+func CheckArguments(name string, count int) error {
+	if function, ok := lookup_scalar(name); ok {
+		return check_arity(name, function.min_arguments, function.max_arguments, count)
+	}
+
+	if function, ok := lookup_aggregate(name); ok {
+		return check_arity(name, function.min_arguments, function.max_arguments, count)
+	}
+
+	if function, ok := window_functions[name]; ok {
+		return check_arity(name, function.min_arguments, function.max_arguments, count)
+	}
+
+	return fmt.Errorf("Function %s does not exist", name)
+}
+
+func check_arity(name string, min_arguments int, max_arguments int, count int) error {
+	switch {
+	case max_arguments == VARIADIC && count < min_arguments:
+		return fmt.Errorf("Function %s takes at least %s", name, arguments(min_arguments))
+	case max_arguments == VARIADIC:
+		return nil
+	case min_arguments == max_arguments && count != min_arguments:
+		return fmt.Errorf("Function %s takes exactly %s", name, arguments(min_arguments))
+	case count < min_arguments || count > max_arguments:
+		return fmt.Errorf("Function %s takes %d to %d arguments", name, min_arguments, max_arguments)
+	}
+
+	return nil
+}
+
+func CallScalar(name string, arguments []types.Value) (types.Value, error) {
+	function, ok := lookup_scalar(name)
+	if !ok {
+		return types.Value{}, fmt.Errorf("Function %s does not exist", name)
+	}
+
+	if err := check_arity(name, function.min_arguments, function.max_arguments, len(arguments)); err != nil {
+		return types.Value{}, err
+	}
+
+	if function.strict {
+		for _, argument := range arguments {
+			if argument.IsNull() {
+				return types.NewNull(), nil
+			}
+		}
+	}
+
+	return function.apply(name, arguments)
+}
+
+func arguments(count int) string {
+	if count == 1 {
+		return "one argument"
+	}
+
+	return fmt.Sprintf("%d arguments", count)
+}
+
+func argument_error(name string, value types.Value) error {
+	return fmt.Errorf("Function %s does not accept %s", name, value.Type())
+}
+
+func text_arguments(name string, arguments []types.Value) ([]string, error) {
+	texts := make([]string, len(arguments))
+	for i, argument := range arguments {
+		if argument.Type() != types.TYPE_TEXT {
+			return nil, argument_error(name, argument)
+		}
+
+		texts[i] = argument.Text()
+	}
+
+	return texts, nil
+}
+
+func check_types(name string, arguments []types.Value, accept func(types.Type) bool) error {
+	for _, argument := range arguments {
+		if !accept(argument.Type()) {
+			return argument_error(name, argument)
+		}
+	}
+
+	return nil
+}
+
+func is_integer(_type types.Type) bool {
+	return _type == types.TYPE_INTEGER
+}
+
+func length(name string, arguments []types.Value) (types.Value, error) {
+	switch value := arguments[0]; value.Type() {
+	case types.TYPE_TEXT:
+		return types.NewInteger(int64(utf8.RuneCountInString(value.Text()))), nil
+	case types.TYPE_BLOB:
+		return types.NewInteger(int64(len(value.Blob()))), nil
+	default:
+		return types.Value{}, argument_error(name, value)
+	}
+}
+
+func upper(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	return types.NewText(strings.ToUpper(texts[0])), nil
+}
+
+func lower(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	return types.NewText(strings.ToLower(texts[0])), nil
+}
+
+func substr(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments[:1])
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	if err := check_types(name, arguments[1:], is_integer); err != nil {
+		return types.Value{}, err
+	}
+
+	runes := []rune(texts[0])
+	size := int64(len(runes))
+	start, end := arguments[1].Integer(), size+1
+
+	if len(arguments) == 3 {
+		count := arguments[2].Integer()
+		if count < 0 {
+			return types.Value{}, errors.New("Negative substring length not allowed")
+		}
+
+		if start > 0 && count > math.MaxInt64-start {
+			count = math.MaxInt64 - start
+		}
+
+		end = start + count
+	}
+
+	if start < 1 {
+		start = 1
+	}
+
+	if end > size+1 {
+		end = size + 1
+	}
+
+	if start >= end {
+		return types.NewText(""), nil
+	}
+
+	return types.NewText(string(runes[start-1 : end-1])), nil
+}
+
+func trim(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	characters := " "
+	if len(texts) == 2 {
+		characters = texts[1]
+	}
+
+	return types.NewText(strings.Trim(texts[0], characters)), nil
+}
+
+func replace(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	if texts[1] == "" {
+		return arguments[0], nil
+	}
+
+	return types.NewText(strings.ReplaceAll(texts[0], texts[1], texts[2])), nil
+}
+
+func instr(name string, arguments []types.Value) (types.Value, error) {
+	texts, err := text_arguments(name, arguments)
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	index := strings.Index(texts[0], texts[1])
+	if index < 0 {
+		return types.NewInteger(0), nil
+	}
+
+	return types.NewInteger(int64(utf8.RuneCountInString(texts[0][:index]) + 1)), nil
+}
+
+func abs(name string, arguments []types.Value) (types.Value, error) {
+	switch value := arguments[0]; value.Type() {
+	case types.TYPE_INTEGER:
+		if value.Integer() < 0 {
+			return types.Negate(value)
+		}
+
+		return value, nil
+	case types.TYPE_REAL:
+		return types.NewReal(math.Abs(value.Real())), nil
+	case types.TYPE_DECIMAL:
+		if value.Decimal().Sign() < 0 {
+			return types.Negate(value)
+		}
+
+		return value, nil
+	default:
+		return types.Value{}, argument_error(name, value)
+	}
+}
+
+func round(name string, arguments []types.Value) (types.Value, error) {
+	if err := check_types(name, arguments[1:], is_integer); err != nil {
+		return types.Value{}, err
+	}
+
+	digits := int64(0)
+	if len(arguments) == 2 {
+		digits = arguments[1].Integer()
+	}
+
+	switch {
+	case digits > math.MaxInt32:
+		digits = math.MaxInt32
+	case digits < math.MinInt32:
+		digits = math.MinInt32
+	}
+
+	switch value := arguments[0]; value.Type() {
+	case types.TYPE_INTEGER:
+		if digits >= 0 {
+			return value, nil
+		}
+
+		decimal, _ := types.Cast(value, types.TYPE_DECIMAL)
+		return types.Cast(types.NewDecimal(decimal.Decimal().Round(int32(digits))), types.TYPE_INTEGER)
+	case types.TYPE_REAL:
+		factor := math.Pow(10, float64(digits))
+		scaled := value.Real() * factor
+
+		switch {
+		case math.IsInf(factor, 0) || math.IsInf(scaled, 0) || math.IsNaN(scaled):
+			return value, nil
+		case factor == 0:
+			return types.NewReal(0), nil
+		}
+
+		return types.NewReal(math.Round(scaled) / factor), nil
+	case types.TYPE_DECIMAL:
+		return types.NewDecimal(value.Decimal().Round(int32(digits))), nil
+	default:
+		return types.Value{}, argument_error(name, value)
+	}
+}
+
+func floor(name string, arguments []types.Value) (types.Value, error) {
+	switch value := arguments[0]; value.Type() {
+	case types.TYPE_INTEGER:
+		return value, nil
+	case types.TYPE_REAL:
+		return types.NewReal(math.Floor(value.Real())), nil
+	case types.TYPE_DECIMAL:
+		return types.NewDecimal(value.Decimal().Floor()), nil
+	default:
+		return types.Value{}, argument_error(name, value)
+	}
+}
+
+func ceil(name string, arguments []types.Value) (types.Value, error) {
+	switch value := arguments[0]; value.Type() {
+	case types.TYPE_INTEGER:
+		return value, nil
+	case types.TYPE_REAL:
+		return types.NewReal(math.Ceil(value.Real())), nil
+	case types.TYPE_DECIMAL:
+		return types.NewDecimal(value.Decimal().Ceil()), nil
+	default:
+		return types.Value{}, argument_error(name, value)
+	}
+}
+
+func mod(name string, arguments []types.Value) (types.Value, error) {
+	if err := check_types(name, arguments, types.Type.IsNumeric); err != nil {
+		return types.Value{}, err
+	}
+
+	return types.Modulo(arguments[0], arguments[1])
+}
+
+func power(name string, arguments []types.Value) (types.Value, error) {
+	if err := check_types(name, arguments, types.Type.IsNumeric); err != nil {
+		return types.Value{}, err
+	}
+
+	left, _ := types.Cast(arguments[0], types.TYPE_REAL)
+	right, _ := types.Cast(arguments[1], types.TYPE_REAL)
+	base, exponent := left.Real(), right.Real()
+
+	if base == 0 && exponent < 0 {
+		return types.Value{}, errors.New("Zero raised to a negative power is undefined")
+	}
+
+	if base < 0 && exponent != math.Trunc(exponent) {
+		return types.Value{}, errors.New("A negative number raised to a non-integer power yields a complex result")
+	}
+
+	result := math.Pow(base, exponent)
+	if math.IsInf(result, 0) && !math.IsInf(base, 0) && !math.IsInf(exponent, 0) {
+		return types.Value{}, types.ErrRealOutOfRange
+	}
+
+	return types.NewReal(result), nil
+}
+
+func coalesce(_ string, arguments []types.Value) (types.Value, error) {
+	for _, argument := range arguments {
+		if !argument.IsNull() {
+			return argument, nil
+		}
+	}
+
+	return types.NewNull(), nil
+}
+
+func nullif(_ string, arguments []types.Value) (types.Value, error) {
+	equal, err := types.COMPARISON_EQUAL.Apply(arguments[0], arguments[1])
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	if types.IsTrue(equal) {
+		return types.NewNull(), nil
+	}
+
+	return arguments[0], nil
+}
@@ -0,0 +1,106 @@
+package functions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func decimal(text string) types.Value {
+	value, _ := types.ParseDecimal(text)
+	return types.NewDecimal(value)
+}
+
+func TestScalarFunctions(t *testing.T) {
+	text, integer, real := types.NewText, types.NewInteger, types.NewReal
+
+	cases := []struct {
+		name      string
+		arguments []types.Value
+		expected  string
+	}{
+		{"length", []types.Value{text("héllo")}, "5"},
+		{"length", []types.Value{types.NewBlob([]byte{1, 2})}, "2"},
+		{"upper", []types.Value{text("abc")}, "ABC"},
+		{"lower", []types.Value{text("ABC")}, "abc"},
+		{"substr", []types.Value{text("héllo"), integer(2)}, "éllo"},
+		{"substr", []types.Value{text("hello"), integer(2), integer(3)}, "ell"},
+		{"substr", []types.Value{text("hello"), integer(0), integer(3)}, "he"},
+		{"substr", []types.Value{text("hello"), integer(9), integer(3)}, ""},
+		{"substr", []types.Value{text("hello"), integer(-10), integer(100)}, "hello"},
+		{"substr", []types.Value{text("hello"), integer(-1), integer(4)}, "he"},
+		{"substr", []types.Value{text("hello"), integer(3), integer(math.MaxInt64)}, "llo"},
+		{"trim", []types.Value{text("  a b  ")}, "a b"},
+		{"trim", []types.Value{text("xxaxx"), text("x")}, "a"},
+		{"replace", []types.Value{text("a-b-c"), text("-"), text("+")}, "a+b+c"},
+		{"replace", []types.Value{text("abc"), text(""), text("+")}, "abc"},
+		{"instr", []types.Value{text("héllo"), text("l")}, "3"},
+		{"instr", []types.Value{text("hello"), text("z")}, "0"},
+		{"abs", []types.Value{integer(-3)}, "3"},
+		{"abs", []types.Value{decimal("-1.50")}, "1.50"},
+		{"round", []types.Value{decimal("2.5")}, "3"},
+		{"round", []types.Value{decimal("2.345"), integer(2)}, "2.35"},
+		{"round", []types.Value{real(-2.5)}, "-3"},
+		{"round", []types.Value{real(1.25), integer(1)}, "1.3"},
+		{"round", []types.Value{integer(1250), integer(-2)}, "1300"},
+		{"floor", []types.Value{decimal("-1.5")}, "-2"},
+		{"ceil", []types.Value{real(1.2)}, "2"},
+		{"ceiling", []types.Value{integer(4)}, "4"},
+		{"mod", []types.Value{integer(7), integer(3)}, "1"},
+		{"power", []types.Value{integer(2), integer(10)}, "1024"},
+		{"coalesce", []types.Value{types.NewNull(), types.NewNull(), integer(1), integer(2)}, "1"},
+		{"coalesce", []types.Value{types.NewNull()}, "NULL"},
+		{"ifnull", []types.Value{types.NewNull(), text("x")}, "x"},
+		{"nullif", []types.Value{integer(1), integer(1)}, "NULL"},
+		{"nullif", []types.Value{integer(1), integer(2)}, "1"},
+		{"nullif", []types.Value{integer(1), types.NewNull()}, "1"},
+		{"upper", []types.Value{types.NewNull()}, "NULL"},
+		{"substr", []types.Value{text("hello"), types.NewNull()}, "NULL"},
+	}
+
+	for _, test := range cases {
+		result, err := CallScalar(test.name, test.arguments)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, result.String(), test.name)
+	}
+}
+
+func TestScalarArguments(t *testing.T) {
+	assert.True(t, IsScalar("coalesce"))
+	assert.False(t, IsScalar("count"))
+
+	assert.NoError(t, CheckArguments("coalesce", 5))
+	assert.EqualError(t, CheckArguments("coalesce", 0), "Function coalesce takes at least one argument")
+	assert.EqualError(t, CheckArguments("upper", 2), "Function upper takes exactly one argument")
+	assert.EqualError(t, CheckArguments("replace", 1), "Function replace takes exactly 3 arguments")
+	assert.EqualError(t, CheckArguments("substr", 4), "Function substr takes 2 to 3 arguments")
+	assert.EqualError(t, CheckArguments("frobnicate", 1), "Function frobnicate does not exist")
+}
+
+func TestScalarErrors(t *testing.T) {
+	_, err := CallScalar("upper", []types.Value{types.NewInteger(1)})
+	assert.EqualError(t, err, "Function upper does not accept INTEGER")
+
+	_, err = CallScalar("abs", []types.Value{types.NewText("1")})
+	assert.EqualError(t, err, "Function abs does not accept TEXT")
+
+	_, err = CallScalar("substr", []types.Value{types.NewText("a"), types.NewInteger(1), types.NewInteger(-1)})
+	assert.EqualError(t, err, "Negative substring length not allowed")
+
+	_, err = CallScalar("mod", []types.Value{types.NewInteger(1), types.NewInteger(0)})
+	assert.Equal(t, types.ErrDivisionByZero, err)
+
+	_, err = CallScalar("power", []types.Value{types.NewInteger(-8), types.NewReal(0.5)})
+	assert.EqualError(t, err, "A negative number raised to a non-integer power yields a complex result")
+
+	_, err = CallScalar("power", []types.Value{types.NewInteger(0), types.NewInteger(-1)})
+	assert.EqualError(t, err, "Zero raised to a negative power is undefined")
+
+	_, err = CallScalar("abs", []types.Value{types.NewInteger(-9223372036854775808)})
+	assert.Equal(t, types.ErrIntegerOutOfRange, err)
+
+	_, err = CallScalar("nullif", []types.Value{types.NewInteger(1), types.NewText("1")})
+	assert.EqualError(t, err, "Cannot compare INTEGER with TEXT")
+}
@@ -0,0 +1,375 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+)
+
+var ErrNegativeFrameOffset = errors.New("Frame offset must not be negative")
+
+type FrameMode uint8
+
+const (
+	FRAME_ROWS FrameMode = iota
+	FRAME_RANGE
+)
+
+var frame_mode_names = [...]string{
+	FRAME_ROWS:  "ROWS",
+	FRAME_RANGE: "RANGE",
+}
+
+func (mode FrameMode) String() string {
+	return frame_mode_names[mode]
+}
+
+type BoundKind uint8
+
+const (
+	BOUND_UNBOUNDED_PRECEDING BoundKind = iota
+	BOUND_PRECEDING
+	BOUND_CURRENT_ROW
+	BOUND_FOLLOWING
+	BOUND_UNBOUNDED_FOLLOWING
+)
+
+type FrameBound struct {
+	kind   BoundKind
+	offset types.Value
+}
+
+func MakeBound(kind BoundKind, offset types.Value) FrameBound {
+	return FrameBound{kind, offset}
+}
+
+type Frame struct {
+	mode  FrameMode
+	start FrameBound
+	end   FrameBound
+}
+
+func MakeFrame(mode FrameMode, start FrameBound, end FrameBound) Frame {
+	return Frame{mode, start, end}
+}
+
+var DefaultFrame = Frame{FRAME_RANGE, FrameBound{kind: BOUND_UNBOUNDED_PRECEDING}, FrameBound{kind: BOUND_CURRENT_ROW}}
+
+type window_function struct {
+	min_arguments int
+	max_arguments int
+}
+
+var window_functions = map[string]window_function{
+	"dense_rank":  {0, 0},
+	"first_value": {1, 1},
+	"lag":         {1, 3},
+	"last_value":  {1, 1},
+	"lead":        {1, 3},
+	"rank":        {0, 0},
+	"row_number":  {0, 0},
+}
+
+func IsWindow(name string) bool {
+	_, ok := window_functions[name]
+	return ok
+}
+
+type Partition struct {
+	descending bool
+	arguments  [][]types.Value
+	keys       []types.Value
+	peers      []int
+}
+
+func NewPartition(descending bool) *Partition {
+	return &Partition{descending: descending}
+}
+
+func (partition *Partition) Append(arguments []types.Value, key types.Value, peer bool) {
+	group := 0
+	if count := len(partition.peers); count > 0 {
+		group = partition.peers[count-1]
+		if !peer {
+			group += 1
+		}
+	}
+
+	partition.arguments = append(partition.arguments, arguments)
+	partition.keys = append(partition.keys, key)
+	partition.peers = append(partition.peers, group)
+}
+
+func (partition *Partition) Evaluate(name string, frame Frame) ([]types.Value, error) {
+	results := make([]types.Value, len(partition.arguments))
+
+	switch name {
+	case "row_number":
+		for i := range results {
+			results[i] = types.NewInteger(int64(i + 1))
+		}
+	case "rank":
+		for i := range results {
+			results[i] = types.NewInteger(int64(partition.peer_start(i) + 1))
+		}
+	case "dense_rank":
+		for i := range results {
+			results[i] = types.NewInteger(int64(partition.peers[i] + 1))
+		}
+	case "lag", "lead":
+		direction := -1
+		if name == "lead" {
+			direction = 1
+		}
+
+		for i := range results {
+			result, err := partition.offset_value(name, i, direction)
+			if err != nil {
+				return nil, err
+			}
+
+			results[i] = result
+		}
+	case "first_value", "last_value":
+		for i := range results {
+			start, end, err := partition.frame_bounds(i, frame)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case start >= end:
+				results[i] = types.NewNull()
+			case name == "first_value":
+				results[i] = partition.arguments[start][0]
+			default:
+				results[i] = partition.arguments[end-1][0]
+			}
+		}
+	default:
+		return partition.evaluate_aggregate(name, frame)
+	}
+
+	return results, nil
+}
+
+func (partition *Partition) evaluate_aggregate(name string, frame Frame) ([]types.Value, error) {
+	results := make([]types.Value, len(partition.arguments))
+	running := frame.start.kind == BOUND_UNBOUNDED_PRECEDING
+
+	var aggregate Aggregate
+	stepped := 0
+	for i := range results {
+		start, end, err := partition.frame_bounds(i, frame)
+		if err != nil {
+			return nil, err
+		}
+
+		if !running || aggregate == nil {
+			if aggregate, err = NewAggregate(name, false); err != nil {
+				return nil, err
+			}
+			stepped = start
+		}
+
+		for ; stepped < end; stepped++ {
+			if err := aggregate.Step(partition.arguments[stepped]...); err != nil {
+				return nil, err
+			}
+		}
+
+		if results[i], err = aggregate.Result(); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func (partition *Partition) offset_value(name string, row int, direction int) (types.Value, error) {
+	arguments := partition.arguments[row]
+
+	offset := types.NewInteger(1)
+	if len(arguments) > 1 {
+		offset = arguments[1]
+	}
+
+	fallback := types.NewNull()
+	if len(arguments) > 2 {
+		fallback = arguments[2]
+	}
+
+	switch {
+	case offset.IsNull():
+		return types.NewNull(), nil
+	case offset.Type() != types.TYPE_INTEGER:
+		return types.Value{}, argument_error(name, offset)
+	}
+
+	size := int64(len(partition.arguments))
+	if offset.Integer() > size || offset.Integer() < -size {
+		return fallback, nil
+	}
+
+	target := int64(row) + int64(direction)*offset.Integer()
+	if target < 0 || target >= size {
+		return fallback, nil
+	}
+
+	return partition.arguments[target][0], nil
+}
+
+func (partition *Partition) peer_start(row int) int {
+	for row > 0 && partition.peers[row-1] == partition.peers[row] {
+		row -= 1
+	}
+
+	return row
+}
+
+func (partition *Partition) peer_end(row int) int {
+	for row < len(partition.peers)-1 && partition.peers[row+1] == partition.peers[row] {
+		row += 1
+	}
+
+	return row + 1
+}
+
+func (partition *Partition) frame_bounds(row int, frame Frame) (int, int, error) {
+	start, err := partition.frame_position(row, frame, frame.start, false)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := partition.frame_position(row, frame, frame.end, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if start < 0 {
+		start = 0
+	}
+	if end > len(partition.arguments) {
+		end = len(partition.arguments)
+	}
+
+	return start, end, nil
+}
+
+func (partition *Partition) frame_position(row int, frame Frame, bound FrameBound, end bool) (int, error) {
+	switch bound.kind {
+	case BOUND_UNBOUNDED_PRECEDING:
+		return 0, nil
+	case BOUND_UNBOUNDED_FOLLOWING:
+		return len(partition.arguments), nil
+	case BOUND_CURRENT_ROW:
+		switch {
+		case frame.mode == FRAME_ROWS && end:
+			return row + 1, nil
+		case frame.mode == FRAME_ROWS:
+			return row, nil
+		case end:
+			return partition.peer_end(row), nil
+		default:
+			return partition.peer_start(row), nil
+		}
+	}
+
+	if bound.offset.IsNull() {
+		return 0, fmt.Errorf("Frame offset for %s must not be NULL", frame.mode)
+	}
+
+	if frame.mode == FRAME_ROWS {
+		return partition.rows_position(row, bound, end)
+	}
+
+	return partition.range_position(row, bound, end)
+}
+
+func (partition *Partition) rows_position(row int, bound FrameBound, end bool) (int, error) {
+	if bound.offset.Type() != types.TYPE_INTEGER {
+		return 0, fmt.Errorf("Frame offset for ROWS must be an INTEGER, not %s", bound.offset.Type())
+	}
+
+	offset := bound.offset.Integer()
+	if offset < 0 {
+		return 0, ErrNegativeFrameOffset
+	}
+
+	if size := int64(len(partition.arguments)); offset > size {
+		offset = size
+	}
+
+	position := row + int(offset)
+	if bound.kind == BOUND_PRECEDING {
+		position = row - int(offset)
+	}
+	if end {
+		position += 1
+	}
+
+	return position, nil
+}
+
+func (partition *Partition) range_position(row int, bound FrameBound, end bool) (int, error) {
+	key := partition.keys[row]
+	switch {
+	case key.IsNull() && end:
+		return partition.peer_end(row), nil
+	case key.IsNull():
+		return partition.peer_start(row), nil
+	}
+
+	var zero types.Value
+	switch {
+	case bound.offset.Type().IsNumeric():
+		zero = types.NewInteger(0)
+	case bound.offset.Type() == types.TYPE_INTERVAL:
+		zero = types.NewInterval(0, 0)
+	default:
+		return 0, fmt.Errorf("Frame offset for RANGE cannot be %s", bound.offset.Type())
+	}
+
+	if sign, err := types.Compare(bound.offset, zero); err != nil || sign < 0 {
+		return 0, ErrNegativeFrameOffset
+	}
+
+	direction, shift := 1, types.Add
+	if partition.descending {
+		direction = -1
+	}
+	if (bound.kind == BOUND_PRECEDING) != partition.descending {
+		shift = types.Subtract
+	}
+
+	target, err := shift(key, bound.offset)
+	if err != nil {
+		return 0, err
+	}
+
+	position := len(partition.keys)
+	if end {
+		position = 0
+	}
+
+	for i, key := range partition.keys {
+		if key.IsNull() {
+			continue
+		}
+
+		result, err := types.Compare(key, target)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case end && result*direction <= 0:
+			position = i + 1
+		case !end && result*direction >= 0:
+			return i, nil
+		}
+	}
+
+	return position, nil
+}
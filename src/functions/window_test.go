@@ -0,0 +1,137 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/JamesErrington/tasiadb/src/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func scores(descending bool, values ...int64) *Partition {
+	partition := NewPartition(descending)
+	for i, value := range values {
+		key := types.NewInteger(value)
+		partition.Append([]types.Value{key}, key, i > 0 && values[i-1] == value)
+	}
+
+	return partition
+}
+
+func stars(count int) *Partition {
+	partition := NewPartition(false)
+	for i := 0; i < count; i++ {
+		partition.Append(nil, types.NewNull(), true)
+	}
+
+	return partition
+}
+
+func results(t *testing.T, partition *Partition, name string, frame Frame) []string {
+	values, err := partition.Evaluate(name, frame)
+	assert.NoError(t, err, name)
+
+	texts := make([]string, len(values))
+	for i, value := range values {
+		texts[i] = value.String()
+	}
+
+	return texts
+}
+
+func TestWindowRanking(t *testing.T) {
+	partition := scores(false, 10, 20, 20, 30, 50)
+
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, results(t, partition, "row_number", DefaultFrame))
+	assert.Equal(t, []string{"1", "2", "2", "4", "5"}, results(t, partition, "rank", DefaultFrame))
+	assert.Equal(t, []string{"1", "2", "2", "3", "4"}, results(t, partition, "dense_rank", DefaultFrame))
+}
+
+func TestWindowOffsets(t *testing.T) {
+	partition := scores(false, 10, 20, 20, 30, 50)
+	assert.Equal(t, []string{"NULL", "10", "20", "20", "30"}, results(t, partition, "lag", DefaultFrame))
+	assert.Equal(t, []string{"20", "20", "30", "50", "NULL"}, results(t, partition, "lead", DefaultFrame))
+
+	partition = NewPartition(false)
+	for _, value := range []int64{10, 20, 30} {
+		partition.Append([]types.Value{types.NewInteger(value), types.NewInteger(2), types.NewInteger(0)}, types.NewNull(), false)
+	}
+	assert.Equal(t, []string{"0", "0", "10"}, results(t, partition, "lag", DefaultFrame))
+	assert.Equal(t, []string{"30", "0", "0"}, results(t, partition, "lead", DefaultFrame))
+
+	partition = NewPartition(false)
+	partition.Append([]types.Value{types.NewInteger(1), types.NewText("1")}, types.NewNull(), false)
+	_, err := partition.Evaluate("lag", DefaultFrame)
+	assert.EqualError(t, err, "Function lag does not accept TEXT")
+}
+
+func TestWindowFrames(t *testing.T) {
+	partition := scores(false, 10, 20, 20, 30, 50)
+	integer := types.NewInteger
+	unbounded_preceding := MakeBound(BOUND_UNBOUNDED_PRECEDING, types.NewNull())
+	unbounded_following := MakeBound(BOUND_UNBOUNDED_FOLLOWING, types.NewNull())
+	current_row := MakeBound(BOUND_CURRENT_ROW, types.NewNull())
+
+	assert.Equal(t, []string{"10", "50", "50", "80", "130"}, results(t, partition, "sum", DefaultFrame))
+	assert.Equal(t, []string{"10", "10", "10", "10", "10"}, results(t, partition, "first_value", DefaultFrame))
+	assert.Equal(t, []string{"10", "20", "20", "30", "50"}, results(t, partition, "last_value", DefaultFrame))
+
+	sliding := MakeFrame(FRAME_ROWS, MakeBound(BOUND_PRECEDING, integer(1)), MakeBound(BOUND_FOLLOWING, integer(1)))
+	assert.Equal(t, []string{"30", "50", "70", "100", "80"}, results(t, partition, "sum", sliding))
+	assert.Equal(t, []string{"10", "10", "20", "20", "30"}, results(t, partition, "min", sliding))
+
+	whole := MakeFrame(FRAME_ROWS, unbounded_preceding, unbounded_following)
+	assert.Equal(t, []string{"50", "50", "50", "50", "50"}, results(t, partition, "last_value", whole))
+	assert.Equal(t, []string{"5", "5", "5", "5", "5"}, results(t, stars(5), "count", whole))
+
+	running := MakeFrame(FRAME_ROWS, unbounded_preceding, current_row)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, results(t, stars(5), "count", running))
+
+	empty := MakeFrame(FRAME_ROWS, MakeBound(BOUND_FOLLOWING, integer(3)), unbounded_following)
+	assert.Equal(t, []string{"30", "50", "NULL", "NULL", "NULL"}, results(t, partition, "first_value", empty))
+	assert.Equal(t, []string{"2", "1", "0", "0", "0"}, results(t, partition, "count", empty))
+
+	ranged := MakeFrame(FRAME_RANGE, MakeBound(BOUND_PRECEDING, integer(10)), current_row)
+	assert.Equal(t, []string{"10", "50", "50", "70", "50"}, results(t, partition, "sum", ranged))
+
+	descending := MakeFrame(FRAME_RANGE, current_row, MakeBound(BOUND_FOLLOWING, integer(10)))
+	assert.Equal(t, []string{"50", "70", "50", "50", "10"}, results(t, scores(true, 50, 30, 20, 20, 10), "sum", descending))
+}
+
+func TestWindowIntervalRange(t *testing.T) {
+	partition := NewPartition(false)
+	for _, text := range []string{"2024-03-01 00:00:00", "2024-03-01 12:00:00", "2024-03-03 00:00:00"} {
+		key := timestamp(text)
+		partition.Append([]types.Value{key}, key, false)
+	}
+
+	frame := MakeFrame(FRAME_RANGE, MakeBound(BOUND_PRECEDING, interval("1 day")), MakeBound(BOUND_CURRENT_ROW, types.NewNull()))
+	assert.Equal(t, []string{"1", "2", "1"}, results(t, partition, "count", frame))
+}
+
+func TestWindowFrameErrors(t *testing.T) {
+	partition := scores(false, 10, 20)
+	current_row := MakeBound(BOUND_CURRENT_ROW, types.NewNull())
+
+	_, err := partition.Evaluate("sum", MakeFrame(FRAME_ROWS, MakeBound(BOUND_PRECEDING, types.NewInteger(-1)), current_row))
+	assert.Equal(t, ErrNegativeFrameOffset, err)
+
+	_, err = partition.Evaluate("sum", MakeFrame(FRAME_ROWS, MakeBound(BOUND_PRECEDING, types.NewNull()), current_row))
+	assert.EqualError(t, err, "Frame offset for ROWS must not be NULL")
+
+	_, err = partition.Evaluate("sum", MakeFrame(FRAME_ROWS, MakeBound(BOUND_PRECEDING, types.NewReal(1.5)), current_row))
+	assert.EqualError(t, err, "Frame offset for ROWS must be an INTEGER, not REAL")
+
+	_, err = partition.Evaluate("sum", MakeFrame(FRAME_RANGE, MakeBound(BOUND_PRECEDING, types.NewText("1")), current_row))
+	assert.EqualError(t, err, "Frame offset for RANGE cannot be TEXT")
+
+	_, err = partition.Evaluate("sum", MakeFrame(FRAME_RANGE, MakeBound(BOUND_PRECEDING, interval("1 day")), current_row))
+	assert.EqualError(t, err, "Cannot apply '-' to INTEGER and INTERVAL")
+
+	assert.True(t, IsWindow("row_number"))
+	assert.False(t, IsWindow("sum"))
+	assert.EqualError(t, CheckArguments("lag", 0), "Function lag takes 1 to 3 arguments")
+	assert.NoError(t, CheckArguments("rank", 0))
+
+	_, err = CallScalar("rank", nil)
+	assert.EqualError(t, err, "Function rank does not exist")
+}
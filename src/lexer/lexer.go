@@ -1,6 +1,8 @@
 package lexer
 
 import (
+	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -11,9 +13,23 @@ const (
 	SYMBOL_LEFT_PAREN   = '('
 	SYMBOL_RIGHT_PAREN  = ')'
 	SYMBOL_SINGLE_QUOTE = '\''
+	SYMBOL_DOUBLE_QUOTE = '"'
+	SYMBOL_BACKTICK     = '`'
 	SYMBOL_UNDERSCORE   = '_'
 	SYMBOL_ASTERISK     = '*'
 	SYMBOL_DOT          = '.'
+	SYMBOL_MINUS        = '-'
+	SYMBOL_PLUS         = '+'
+	SYMBOL_SLASH        = '/'
+	SYMBOL_PERCENT      = '%'
+	SYMBOL_PIPE         = '|'
+	SYMBOL_BACKSLASH    = '\\'
+	SYMBOL_EQUAL        = '='
+	SYMBOL_LESS         = '<'
+	SYMBOL_GREATER      = '>'
+	SYMBOL_BANG         = '!'
+	SYMBOL_QUESTION     = '?'
+	SYMBOL_DOLLAR       = '$'
 	SYMBOL_SPACE        = ' '
 	SYMBOL_TAB          = '\t'
 	SYMBOL_NEWLINE      = '\n'
@@ -30,6 +46,18 @@ const (
 	TOKEN_LEFT_PAREN
 	TOKEN_RIGHT_PAREN
 	TOKEN_ASTERISK
+	TOKEN_DOT
+	TOKEN_MINUS
+	TOKEN_PLUS
+	TOKEN_SLASH
+	TOKEN_PERCENT
+	TOKEN_CONCAT
+	TOKEN_EQUAL
+	TOKEN_NOT_EQUAL
+	TOKEN_LESS
+	TOKEN_LESS_EQUAL
+	TOKEN_GREATER
+	TOKEN_GREATER_EQUAL
 
 	TOKEN_KEYWORD_CREATE
 	TOKEN_KEYWORD_TABLE
@@ -43,12 +71,262 @@ const (
 	TOKEN_KEYWORD_FALSE
 	TOKEN_KEYWORD_SELECT
 	TOKEN_KEYWORD_FROM
+	TOKEN_KEYWORD_INTEGER
+	TOKEN_KEYWORD_REAL
+	TOKEN_KEYWORD_DECIMAL
+	TOKEN_KEYWORD_BLOB
+	TOKEN_KEYWORD_DATE
+	TOKEN_KEYWORD_TIME
+	TOKEN_KEYWORD_TIMESTAMP
+	TOKEN_KEYWORD_NULL
+	TOKEN_KEYWORD_IS
+	TOKEN_KEYWORD_NOT
+	TOKEN_KEYWORD_AND
+	TOKEN_KEYWORD_OR
+	TOKEN_KEYWORD_WHERE
+	TOKEN_KEYWORD_CONSTRAINT
+	TOKEN_KEYWORD_PRIMARY
+	TOKEN_KEYWORD_KEY
+	TOKEN_KEYWORD_UNIQUE
+	TOKEN_KEYWORD_DEFAULT
+	TOKEN_KEYWORD_CHECK
+	TOKEN_KEYWORD_REFERENCES
+	TOKEN_KEYWORD_FOREIGN
+	TOKEN_KEYWORD_ON
+	TOKEN_KEYWORD_DELETE
+	TOKEN_KEYWORD_UPDATE
+	TOKEN_KEYWORD_CASCADE
+	TOKEN_KEYWORD_RESTRICT
+	TOKEN_KEYWORD_SET
+	TOKEN_KEYWORD_NO
+	TOKEN_KEYWORD_ACTION
+	TOKEN_KEYWORD_DEFERRABLE
+	TOKEN_KEYWORD_INITIALLY
+	TOKEN_KEYWORD_DEFERRED
+	TOKEN_KEYWORD_IMMEDIATE
+	TOKEN_KEYWORD_AUTOINCREMENT
+	TOKEN_KEYWORD_GENERATED
+	TOKEN_KEYWORD_ALWAYS
+	TOKEN_KEYWORD_BY
+	TOKEN_KEYWORD_AS
+	TOKEN_KEYWORD_IDENTITY
+	TOKEN_KEYWORD_RETURNING
+	TOKEN_KEYWORD_CONFLICT
+	TOKEN_KEYWORD_DO
+	TOKEN_KEYWORD_NOTHING
+	TOKEN_KEYWORD_ORDER
+	TOKEN_KEYWORD_ASC
+	TOKEN_KEYWORD_DESC
+	TOKEN_KEYWORD_NULLS
+	TOKEN_KEYWORD_FIRST
+	TOKEN_KEYWORD_LAST
+	TOKEN_KEYWORD_LIMIT
+	TOKEN_KEYWORD_OFFSET
+	TOKEN_KEYWORD_DISTINCT
+	TOKEN_KEYWORD_ALL
+	TOKEN_KEYWORD_GROUP
+	TOKEN_KEYWORD_HAVING
+	TOKEN_KEYWORD_JOIN
+	TOKEN_KEYWORD_INNER
+	TOKEN_KEYWORD_LEFT
+	TOKEN_KEYWORD_RIGHT
+	TOKEN_KEYWORD_FULL
+	TOKEN_KEYWORD_OUTER
+	TOKEN_KEYWORD_CROSS
+	TOKEN_KEYWORD_USING
+	TOKEN_KEYWORD_EXISTS
+	TOKEN_KEYWORD_IN
+	TOKEN_KEYWORD_UNION
+	TOKEN_KEYWORD_INTERSECT
+	TOKEN_KEYWORD_EXCEPT
+	TOKEN_KEYWORD_WITH
+	TOKEN_KEYWORD_RECURSIVE
+	TOKEN_KEYWORD_CASE
+	TOKEN_KEYWORD_WHEN
+	TOKEN_KEYWORD_THEN
+	TOKEN_KEYWORD_ELSE
+	TOKEN_KEYWORD_END
+	TOKEN_KEYWORD_CAST
+	TOKEN_KEYWORD_LIKE
+	TOKEN_KEYWORD_ILIKE
+	TOKEN_KEYWORD_GLOB
+	TOKEN_KEYWORD_REGEXP
+	TOKEN_KEYWORD_ESCAPE
+	TOKEN_KEYWORD_BETWEEN
+	TOKEN_KEYWORD_INTERVAL
+	TOKEN_KEYWORD_OVER
+	TOKEN_KEYWORD_PARTITION
+	TOKEN_KEYWORD_ROWS
+	TOKEN_KEYWORD_RANGE
+	TOKEN_KEYWORD_UNBOUNDED
+	TOKEN_KEYWORD_PRECEDING
+	TOKEN_KEYWORD_FOLLOWING
+	TOKEN_KEYWORD_CURRENT
+	TOKEN_KEYWORD_ROW
+	TOKEN_KEYWORD_DROP
+	TOKEN_KEYWORD_INDEX
 
 	TOKEN_IDENTIFIER
-	TOKEN_LITERAL_NUMBER
+	TOKEN_LITERAL_INTEGER
+	TOKEN_LITERAL_REAL
 	TOKEN_LITERAL_TEXT
+	TOKEN_LITERAL_BLOB
+	TOKEN_PARAMETER
 )
 
+var keywords = map[string]TokenType{
+	"ACTION":        TOKEN_KEYWORD_ACTION,
+	"ALL":           TOKEN_KEYWORD_ALL,
+	"ALWAYS":        TOKEN_KEYWORD_ALWAYS,
+	"AND":           TOKEN_KEYWORD_AND,
+	"AS":            TOKEN_KEYWORD_AS,
+	"ASC":           TOKEN_KEYWORD_ASC,
+	"AUTOINCREMENT": TOKEN_KEYWORD_AUTOINCREMENT,
+	"BETWEEN":       TOKEN_KEYWORD_BETWEEN,
+	"BLOB":          TOKEN_KEYWORD_BLOB,
+	"BOOLEAN":       TOKEN_KEYWORD_BOOLEAN,
+	"BY":            TOKEN_KEYWORD_BY,
+	"CASCADE":       TOKEN_KEYWORD_CASCADE,
+	"CASE":          TOKEN_KEYWORD_CASE,
+	"CAST":          TOKEN_KEYWORD_CAST,
+	"CHECK":         TOKEN_KEYWORD_CHECK,
+	"CONFLICT":      TOKEN_KEYWORD_CONFLICT,
+	"CONSTRAINT":    TOKEN_KEYWORD_CONSTRAINT,
+	"CREATE":        TOKEN_KEYWORD_CREATE,
+	"CROSS":         TOKEN_KEYWORD_CROSS,
+	"CURRENT":       TOKEN_KEYWORD_CURRENT,
+	"DATE":          TOKEN_KEYWORD_DATE,
+	"DECIMAL":       TOKEN_KEYWORD_DECIMAL,
+	"DEFAULT":       TOKEN_KEYWORD_DEFAULT,
+	"DEFERRABLE":    TOKEN_KEYWORD_DEFERRABLE,
+	"DEFERRED":      TOKEN_KEYWORD_DEFERRED,
+	"DELETE":        TOKEN_KEYWORD_DELETE,
+	"DESC":          TOKEN_KEYWORD_DESC,
+	"DISTINCT":      TOKEN_KEYWORD_DISTINCT,
+	"DO":            TOKEN_KEYWORD_DO,
+	"DROP":          TOKEN_KEYWORD_DROP,
+	"ELSE":          TOKEN_KEYWORD_ELSE,
+	"END":           TOKEN_KEYWORD_END,
+	"ESCAPE":        TOKEN_KEYWORD_ESCAPE,
+	"EXCEPT":        TOKEN_KEYWORD_EXCEPT,
+	"EXISTS":        TOKEN_KEYWORD_EXISTS,
+	"FALSE":         TOKEN_KEYWORD_FALSE,
+	"FIRST":         TOKEN_KEYWORD_FIRST,
+	"FOLLOWING":     TOKEN_KEYWORD_FOLLOWING,
+	"FOREIGN":       TOKEN_KEYWORD_FOREIGN,
+	"FROM":          TOKEN_KEYWORD_FROM,
+	"FULL":          TOKEN_KEYWORD_FULL,
+	"GENERATED":     TOKEN_KEYWORD_GENERATED,
+	"GLOB":          TOKEN_KEYWORD_GLOB,
+	"GROUP":         TOKEN_KEYWORD_GROUP,
+	"HAVING":        TOKEN_KEYWORD_HAVING,
+	"IDENTITY":      TOKEN_KEYWORD_IDENTITY,
+	"ILIKE":         TOKEN_KEYWORD_ILIKE,
+	"IMMEDIATE":     TOKEN_KEYWORD_IMMEDIATE,
+	"IN":            TOKEN_KEYWORD_IN,
+	"INDEX":         TOKEN_KEYWORD_INDEX,
+	"INITIALLY":     TOKEN_KEYWORD_INITIALLY,
+	"INNER":         TOKEN_KEYWORD_INNER,
+	"INSERT":        TOKEN_KEYWORD_INSERT,
+	"INTEGER":       TOKEN_KEYWORD_INTEGER,
+	"INTERSECT":     TOKEN_KEYWORD_INTERSECT,
+	"INTERVAL":      TOKEN_KEYWORD_INTERVAL,
+	"INTO":          TOKEN_KEYWORD_INTO,
+	"IS":            TOKEN_KEYWORD_IS,
+	"JOIN":          TOKEN_KEYWORD_JOIN,
+	"KEY":           TOKEN_KEYWORD_KEY,
+	"LAST":          TOKEN_KEYWORD_LAST,
+	"LEFT":          TOKEN_KEYWORD_LEFT,
+	"LIKE":          TOKEN_KEYWORD_LIKE,
+	"LIMIT":         TOKEN_KEYWORD_LIMIT,
+	"NO":            TOKEN_KEYWORD_NO,
+	"NOT":           TOKEN_KEYWORD_NOT,
+	"NOTHING":       TOKEN_KEYWORD_NOTHING,
+	"NULL":          TOKEN_KEYWORD_NULL,
+	"NULLS":         TOKEN_KEYWORD_NULLS,
+	"NUMBER":        TOKEN_KEYWORD_NUMBER,
+	"OFFSET":        TOKEN_KEYWORD_OFFSET,
+	"ON":            TOKEN_KEYWORD_ON,
+	"OR":            TOKEN_KEYWORD_OR,
+	"ORDER":         TOKEN_KEYWORD_ORDER,
+	"OUTER":         TOKEN_KEYWORD_OUTER,
+	"OVER":          TOKEN_KEYWORD_OVER,
+	"PARTITION":     TOKEN_KEYWORD_PARTITION,
+	"PRECEDING":     TOKEN_KEYWORD_PRECEDING,
+	"PRIMARY":       TOKEN_KEYWORD_PRIMARY,
+	"RANGE":         TOKEN_KEYWORD_RANGE,
+	"REAL":          TOKEN_KEYWORD_REAL,
+	"RECURSIVE":     TOKEN_KEYWORD_RECURSIVE,
+	"REFERENCES":    TOKEN_KEYWORD_REFERENCES,
+	"REGEXP":        TOKEN_KEYWORD_REGEXP,
+	"RESTRICT":      TOKEN_KEYWORD_RESTRICT,
+	"RETURNING":     TOKEN_KEYWORD_RETURNING,
+	"RIGHT":         TOKEN_KEYWORD_RIGHT,
+	"ROW":           TOKEN_KEYWORD_ROW,
+	"ROWS":          TOKEN_KEYWORD_ROWS,
+	"SELECT":        TOKEN_KEYWORD_SELECT,
+	"SET":           TOKEN_KEYWORD_SET,
+	"TABLE":         TOKEN_KEYWORD_TABLE,
+	"TEXT":          TOKEN_KEYWORD_TEXT,
+	"THEN":          TOKEN_KEYWORD_THEN,
+	"TIME":          TOKEN_KEYWORD_TIME,
+	"TIMESTAMP":     TOKEN_KEYWORD_TIMESTAMP,
+	"TRUE":          TOKEN_KEYWORD_TRUE,
+	"UNBOUNDED":     TOKEN_KEYWORD_UNBOUNDED,
+	"UNION":         TOKEN_KEYWORD_UNION,
+	"UNIQUE":        TOKEN_KEYWORD_UNIQUE,
+	"UPDATE":        TOKEN_KEYWORD_UPDATE,
+	"USING":         TOKEN_KEYWORD_USING,
+	"VALUES":        TOKEN_KEYWORD_VALUES,
+	"WHEN":          TOKEN_KEYWORD_WHEN,
+	"WHERE":         TOKEN_KEYWORD_WHERE,
+	"WITH":          TOKEN_KEYWORD_WITH,
+}
+
+var keyword_names = func() map[TokenType]string {
+	names := make(map[TokenType]string, len(keywords))
+	for name, token_type := range keywords {
+		names[token_type] = name
+	}
+
+	return names
+}()
+
+var unreserved_keywords = map[TokenType]bool{
+	TOKEN_KEYWORD_ACTION:    true,
+	TOKEN_KEYWORD_ALWAYS:    true,
+	TOKEN_KEYWORD_CASCADE:   true,
+	TOKEN_KEYWORD_CONFLICT:  true,
+	TOKEN_KEYWORD_CURRENT:   true,
+	TOKEN_KEYWORD_DATE:      true,
+	TOKEN_KEYWORD_DEFERRED:  true,
+	TOKEN_KEYWORD_END:       true,
+	TOKEN_KEYWORD_FIRST:     true,
+	TOKEN_KEYWORD_FOLLOWING: true,
+	TOKEN_KEYWORD_FULL:      true,
+	TOKEN_KEYWORD_GENERATED: true,
+	TOKEN_KEYWORD_IDENTITY:  true,
+	TOKEN_KEYWORD_IMMEDIATE: true,
+	TOKEN_KEYWORD_INDEX:     true,
+	TOKEN_KEYWORD_KEY:       true,
+	TOKEN_KEYWORD_LAST:      true,
+	TOKEN_KEYWORD_LEFT:      true,
+	TOKEN_KEYWORD_NO:        true,
+	TOKEN_KEYWORD_NOTHING:   true,
+	TOKEN_KEYWORD_NULLS:     true,
+	TOKEN_KEYWORD_PARTITION: true,
+	TOKEN_KEYWORD_PRECEDING: true,
+	TOKEN_KEYWORD_RANGE:     true,
+	TOKEN_KEYWORD_RESTRICT:  true,
+	TOKEN_KEYWORD_RIGHT:     true,
+	TOKEN_KEYWORD_ROW:       true,
+	TOKEN_KEYWORD_ROWS:      true,
+	TOKEN_KEYWORD_SET:       true,
+	TOKEN_KEYWORD_TIME:      true,
+	TOKEN_KEYWORD_TIMESTAMP: true,
+	TOKEN_KEYWORD_UNBOUNDED: true,
+}
+
 func is_whitespace(char rune) bool {
 	switch char {
 	case SYMBOL_SPACE, SYMBOL_NEWLINE, SYMBOL_TAB:
@@ -59,15 +337,19 @@ func is_whitespace(char rune) bool {
 }
 
 func is_alphabetical(char rune) bool {
-	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+	return unicode.IsLetter(char)
 }
 
 func is_digit(char rune) bool {
 	return char >= '0' && char <= '9'
 }
 
+func is_hex_digit(char rune) bool {
+	return is_digit(char) || (char >= 'a' && char <= 'f') || (char >= 'A' && char <= 'F')
+}
+
 func is_alphanumeric(char rune) bool {
-	return is_alphabetical(char) || is_digit(char) || char == '_'
+	return is_alphabetical(char) || unicode.IsDigit(char) || unicode.IsMark(char) || char == '_'
 }
 
 func to_upper_rune(char rune) rune {
@@ -92,12 +374,46 @@ func (token Token) IsTokenType(token_type TokenType) bool {
 	return token._type == token_type
 }
 
+func (token Token) IsUnreserved() bool {
+	return unreserved_keywords[token._type]
+}
+
+func (token Token) AsIdentifier() Token {
+	return Token{TOKEN_IDENTIFIER, strings.ToLower(keyword_names[token._type]), token.offset}
+}
+
 func (token Token) IsDataType() bool {
-	return token._type == TOKEN_KEYWORD_BOOLEAN || token._type == TOKEN_KEYWORD_NUMBER || token._type == TOKEN_KEYWORD_TEXT
+	switch token._type {
+	case TOKEN_KEYWORD_BOOLEAN, TOKEN_KEYWORD_NUMBER, TOKEN_KEYWORD_TEXT, TOKEN_KEYWORD_INTEGER, TOKEN_KEYWORD_REAL,
+		TOKEN_KEYWORD_DECIMAL, TOKEN_KEYWORD_BLOB, TOKEN_KEYWORD_DATE, TOKEN_KEYWORD_TIME, TOKEN_KEYWORD_TIMESTAMP,
+		TOKEN_KEYWORD_INTERVAL:
+		return true
+	default:
+		return false
+	}
 }
 
 func (token Token) IsValueType() bool {
-	return token._type == TOKEN_LITERAL_NUMBER || token._type == TOKEN_LITERAL_TEXT || token._type == TOKEN_KEYWORD_FALSE || token._type == TOKEN_KEYWORD_TRUE
+	switch token._type {
+	case TOKEN_LITERAL_INTEGER, TOKEN_LITERAL_REAL, TOKEN_LITERAL_TEXT, TOKEN_LITERAL_BLOB, TOKEN_KEYWORD_FALSE, TOKEN_KEYWORD_TRUE,
+		TOKEN_KEYWORD_NULL:
+		return true
+	default:
+		return false
+	}
+}
+
+func (token Token) IsComparisonOperator() bool {
+	switch token._type {
+	case TOKEN_EQUAL, TOKEN_NOT_EQUAL, TOKEN_LESS, TOKEN_LESS_EQUAL, TOKEN_GREATER, TOKEN_GREATER_EQUAL:
+		return true
+	default:
+		return false
+	}
+}
+
+func (token Token) Type() TokenType {
+	return token._type
 }
 
 func (token Token) Value() string {
@@ -112,6 +428,7 @@ type Lexer struct {
 	source        string
 	source_length int
 	index         int
+	width         int
 	start         int
 }
 
@@ -120,15 +437,15 @@ func (lexer *Lexer) StartIndex() int {
 }
 
 func NewLexer(source string) *Lexer {
-	return &Lexer{source, len(source), -1, 0}
+	return &Lexer{source, len(source), -1, 1, 0}
 }
 
 func (lexer *Lexer) NextToken() (Token, bool) {
 	for lexer.index < lexer.source_length {
 		char := lexer.next_rune()
 
-		if char < rune(SYMBOL_EOF) || char > utf8.MaxRune {
-			return Token{TOKEN_ERROR, string(char), lexer.index}, false
+		if lexer.is_invalid_rune(char) {
+			return Token{TOKEN_ERROR, "Invalid UTF-8 sequence", lexer.index}, false
 		}
 
 		if is_whitespace(char) {
@@ -148,14 +465,94 @@ func (lexer *Lexer) NextToken() (Token, bool) {
 			return Token{TOKEN_RIGHT_PAREN, "", lexer.index}, false
 		case SYMBOL_ASTERISK:
 			return Token{TOKEN_ASTERISK, "", lexer.index}, false
+		case SYMBOL_DOT:
+			if is_digit(lexer.peek_rune()) {
+				token := lexer.lex_number()
+				return token, false
+			}
+
+			return Token{TOKEN_DOT, "", lexer.index}, false
 		case SYMBOL_SINGLE_QUOTE:
-			token := lexer.lex_text()
+			token := lexer.lex_text(false)
+			return token, false
+		case SYMBOL_DOUBLE_QUOTE, SYMBOL_BACKTICK:
+			token := lexer.lex_quoted_identifier(char)
 			return token, false
+		case SYMBOL_MINUS:
+			if lexer.peek_rune() == SYMBOL_MINUS {
+				lexer.skip_line_comment()
+				continue
+			}
+
+			return Token{TOKEN_MINUS, "", lexer.index}, false
+		case SYMBOL_PLUS:
+			return Token{TOKEN_PLUS, "", lexer.index}, false
+		case SYMBOL_EQUAL:
+			return Token{TOKEN_EQUAL, "", lexer.index}, false
+		case SYMBOL_LESS:
+			start := lexer.index
+			switch lexer.peek_rune() {
+			case SYMBOL_EQUAL:
+				lexer.next_rune()
+				return Token{TOKEN_LESS_EQUAL, "", start}, false
+			case SYMBOL_GREATER:
+				lexer.next_rune()
+				return Token{TOKEN_NOT_EQUAL, "", start}, false
+			}
+
+			return Token{TOKEN_LESS, "", start}, false
+		case SYMBOL_GREATER:
+			start := lexer.index
+			if lexer.peek_rune() == SYMBOL_EQUAL {
+				lexer.next_rune()
+				return Token{TOKEN_GREATER_EQUAL, "", start}, false
+			}
+
+			return Token{TOKEN_GREATER, "", start}, false
+		case SYMBOL_BANG:
+			start := lexer.index
+			if lexer.peek_rune() == SYMBOL_EQUAL {
+				lexer.next_rune()
+				return Token{TOKEN_NOT_EQUAL, "", start}, false
+			}
+
+			return Token{TOKEN_ERROR, "Unidentified token", start}, false
+		case SYMBOL_QUESTION:
+			return Token{TOKEN_PARAMETER, "", lexer.index}, false
+		case SYMBOL_DOLLAR:
+			token := lexer.lex_parameter()
+			return token, false
+		case SYMBOL_SLASH:
+			if lexer.peek_rune() == SYMBOL_ASTERISK {
+				start := lexer.index
+				if !lexer.skip_block_comment() {
+					return Token{TOKEN_ERROR, "Non-terminated block comment", start}, false
+				}
+				continue
+			}
+
+			return Token{TOKEN_SLASH, "", lexer.index}, false
+		case SYMBOL_PERCENT:
+			return Token{TOKEN_PERCENT, "", lexer.index}, false
+		case SYMBOL_PIPE:
+			start := lexer.index
+			if lexer.peek_rune() == SYMBOL_PIPE {
+				lexer.next_rune()
+				return Token{TOKEN_CONCAT, "", start}, false
+			}
+
+			return Token{TOKEN_ERROR, "Unidentified token", start}, false
 		default:
 			switch {
-			case is_digit(char) || char == rune(SYMBOL_DOT):
+			case is_digit(char):
 				token := lexer.lex_number()
 				return token, false
+			case to_upper_rune(char) == 'E' && lexer.peek_rune() == SYMBOL_SINGLE_QUOTE:
+				token := lexer.lex_text(true)
+				return token, false
+			case to_upper_rune(char) == 'X' && lexer.peek_rune() == SYMBOL_SINGLE_QUOTE:
+				token := lexer.lex_blob()
+				return token, false
 			case is_alphabetical(char):
 				token := lexer.lex_keyword_or_identifier()
 				return token, false
@@ -169,146 +566,363 @@ func (lexer *Lexer) NextToken() (Token, bool) {
 }
 
 func (lexer *Lexer) next_rune() rune {
-	lexer.index += 1
+	lexer.index += lexer.width
 
 	if lexer.index >= lexer.source_length {
+		lexer.index = lexer.source_length
+		lexer.width = 0
 		return rune(SYMBOL_EOF)
 	}
 
-	char := rune(lexer.source[lexer.index])
+	char, width := utf8.DecodeRuneInString(lexer.source[lexer.index:])
+	lexer.width = width
+
+	return char
+}
+
+func (lexer *Lexer) backup() {
+	_, width := utf8.DecodeLastRuneInString(lexer.source[:lexer.index])
+	lexer.index -= width
+	lexer.width = width
+}
+
+func (lexer *Lexer) peek_rune() rune {
+	char := lexer.next_rune()
+	lexer.backup()
 
 	return char
 }
 
+func (lexer *Lexer) end_index() int {
+	return lexer.index + lexer.width
+}
+
+func (lexer *Lexer) is_invalid_rune(char rune) bool {
+	return char == utf8.RuneError && lexer.width == 1
+}
+
+func (lexer *Lexer) skip_line_comment() {
+	for {
+		char := lexer.next_rune()
+
+		if char == SYMBOL_NEWLINE || char == SYMBOL_EOF {
+			lexer.backup()
+			return
+		}
+	}
+}
+
+func (lexer *Lexer) skip_block_comment() bool {
+	lexer.next_rune()
+
+	for lexer.index < lexer.source_length {
+		char := lexer.next_rune()
+
+		if char == SYMBOL_ASTERISK && lexer.peek_rune() == SYMBOL_SLASH {
+			lexer.next_rune()
+			return true
+		}
+	}
+
+	lexer.backup()
+	return false
+}
+
 func (lexer *Lexer) lex_number() Token {
 	lexer.start = lexer.index
-	lexer.index -= 1
-	seen_dot := false
-	has_error := false
+	lexer.backup()
+	token_type := TOKEN_LITERAL_INTEGER
+	is_valid := true
+	var value strings.Builder
+
+	if prefix := lexer.source[lexer.start:]; len(prefix) > 1 && prefix[0] == '0' && to_upper_rune(rune(prefix[1])) == 'X' {
+		lexer.next_rune()
+		lexer.next_rune()
+		value.WriteString("0x")
+
+		digits, ok := lexer.lex_digits(&value, is_hex_digit)
+		is_valid = ok && digits > 0
+	} else {
+		digits, ok := lexer.lex_digits(&value, is_digit)
+		is_valid = ok
+
+		if lexer.peek_rune() == SYMBOL_DOT {
+			lexer.next_rune()
+			value.WriteRune(SYMBOL_DOT)
+			token_type = TOKEN_LITERAL_REAL
+
+			fraction_digits, ok := lexer.lex_digits(&value, is_digit)
+			is_valid = is_valid && ok
+			digits += fraction_digits
+		}
 
-	for lexer.index < lexer.source_length {
+		if digits == 0 {
+			is_valid = false
+		}
+
+		if to_upper_rune(lexer.peek_rune()) == 'E' {
+			lexer.next_rune()
+			value.WriteRune('e')
+			token_type = TOKEN_LITERAL_REAL
+
+			if sign := lexer.peek_rune(); sign == SYMBOL_PLUS || sign == SYMBOL_MINUS {
+				lexer.next_rune()
+				value.WriteRune(sign)
+			}
+
+			exponent_digits, ok := lexer.lex_digits(&value, is_digit)
+			is_valid = is_valid && ok && exponent_digits > 0
+		}
+	}
+
+	for char := lexer.peek_rune(); is_alphanumeric(char) || char == SYMBOL_DOT; char = lexer.peek_rune() {
+		lexer.next_rune()
+		is_valid = false
+	}
+
+	if !is_valid {
+		return Token{TOKEN_ERROR, "Invalid number literal", lexer.start}
+	}
+
+	return Token{token_type, value.String(), lexer.start}
+}
+
+func (lexer *Lexer) lex_digits(value *strings.Builder, is_valid_digit func(rune) bool) (int, bool) {
+	digits := 0
+	is_valid := true
+	after_underscore := false
+
+	for {
 		char := lexer.next_rune()
 
-		if char == rune(SYMBOL_DOT) {
-			if seen_dot {
-				has_error = true
+		if char == SYMBOL_UNDERSCORE {
+			if digits == 0 || after_underscore {
+				is_valid = false
 			}
 
-			seen_dot = true
+			after_underscore = true
 			continue
 		}
 
-		if !is_digit(char) {
-			lexer.index -= 1
+		if !is_valid_digit(char) {
+			lexer.backup()
 			break
 		}
+
+		value.WriteRune(char)
+		digits += 1
+		after_underscore = false
+	}
+
+	return digits, is_valid && !after_underscore
+}
+
+func (lexer *Lexer) lex_parameter() Token {
+	start := lexer.index
+
+	var value strings.Builder
+	for is_digit(lexer.peek_rune()) {
+		value.WriteRune(lexer.next_rune())
 	}
 
-	if seen_dot && lexer.index == lexer.start {
-		has_error = true
+	if value.Len() == 0 {
+		return Token{TOKEN_ERROR, "Unidentified token", start}
 	}
 
-	if has_error {
-		return Token{TOKEN_ERROR, "Invalid number literal", lexer.start}
+	if is_alphanumeric(lexer.peek_rune()) {
+		for is_alphanumeric(lexer.peek_rune()) {
+			lexer.next_rune()
+		}
+
+		return Token{TOKEN_ERROR, "Invalid parameter", start}
 	}
 
-	return Token{TOKEN_LITERAL_NUMBER, lexer.source[lexer.start : lexer.index+1], lexer.start}
+	return Token{TOKEN_PARAMETER, value.String(), start}
 }
 
-func (lexer *Lexer) lex_text() Token {
-	lexer.start = lexer.index
+func (lexer *Lexer) lex_text(escapes bool) Token {
+	return lexer.lex_quoted(TOKEN_LITERAL_TEXT, SYMBOL_SINGLE_QUOTE, escapes)
+}
 
-	lexer.next_rune()
+func (lexer *Lexer) lex_blob() Token {
+	token := lexer.lex_quoted(TOKEN_LITERAL_BLOB, SYMBOL_SINGLE_QUOTE, false)
 
-	for lexer.index < lexer.source_length {
-		char := lexer.next_rune()
+	if !token.IsTokenType(TOKEN_LITERAL_BLOB) {
+		return token
+	}
+
+	if len(token.value)%2 != 0 {
+		return Token{TOKEN_ERROR, "Invalid blob literal", token.offset}
+	}
 
-		if char == rune(SYMBOL_SINGLE_QUOTE) {
-			return Token{TOKEN_LITERAL_TEXT, lexer.source[lexer.start+1 : lexer.index], lexer.start}
+	for _, char := range token.value {
+		if !is_hex_digit(char) {
+			return Token{TOKEN_ERROR, "Invalid blob literal", token.offset}
 		}
 	}
 
-	lexer.index -= 1
-	return Token{TOKEN_ERROR, "Non-terminated text literal", lexer.index}
+	return token
 }
 
-func (lexer *Lexer) lex_keyword_or_identifier() Token {
+func (lexer *Lexer) lex_quoted_identifier(quote rune) Token {
+	token := lexer.lex_quoted(TOKEN_IDENTIFIER, quote, false)
+
+	if token.IsTokenType(TOKEN_IDENTIFIER) && token.value == "" {
+		return Token{TOKEN_ERROR, "Zero-length quoted identifier", token.offset}
+	}
+
+	return token
+}
+
+func (lexer *Lexer) lex_quoted(token_type TokenType, quote rune, escapes bool) Token {
 	lexer.start = lexer.index
+	invalid_index := -1
+	escape_index := -1
+	var value strings.Builder
 
-	for {
+	if lexer.source[lexer.index] != byte(quote) {
+		lexer.next_rune()
+	}
+
+	for lexer.index < lexer.source_length {
 		char := lexer.next_rune()
 
-		if !is_alphanumeric(char) {
-			lexer.index -= 1
-			break
+		if lexer.is_invalid_rune(char) && invalid_index < 0 {
+			invalid_index = lexer.index
 		}
-	}
 
-	char := rune(lexer.source[lexer.start])
-	switch to_upper_rune(char) {
-	case 'B':
-		return lexer.check_keyword(1, 6, "OOLEAN", TOKEN_KEYWORD_BOOLEAN)
-	case 'C':
-		return lexer.check_keyword(1, 5, "REATE", TOKEN_KEYWORD_CREATE)
-	case 'F':
-		if lexer.index-lexer.start > 1 {
-			char = rune(lexer.source[lexer.start+1])
-			switch to_upper_rune(char) {
-			case 'A':
-				return lexer.check_keyword(2, 3, "LSE", TOKEN_KEYWORD_FALSE)
-			case 'R':
-				return lexer.check_keyword(2, 2, "OM", TOKEN_KEYWORD_FROM)
+		if char == quote {
+			if lexer.peek_rune() == quote {
+				lexer.next_rune()
+				value.WriteRune(char)
+				continue
 			}
-		}
-	case 'I':
-		if lexer.index-lexer.start > 1 {
-			char = rune(lexer.source[lexer.start+1])
-			switch to_upper_rune(char) {
-			case 'N':
-				if lexer.index-lexer.start > 2 {
-					char = rune(lexer.source[lexer.start+2])
-					switch to_upper_rune(char) {
-					case 'S':
-						return lexer.check_keyword(3, 3, "ERT", TOKEN_KEYWORD_INSERT)
-					case 'T':
-						return lexer.check_keyword(3, 1, "O", TOKEN_KEYWORD_INTO)
-					}
-				}
+
+			if invalid_index >= 0 {
+				return Token{TOKEN_ERROR, "Invalid UTF-8 sequence", invalid_index}
+			}
+
+			if escape_index >= 0 {
+				return Token{TOKEN_ERROR, "Invalid escape sequence", escape_index}
+			}
+
+			if !utf8.ValidString(value.String()) {
+				return Token{TOKEN_ERROR, "Invalid UTF-8 sequence", lexer.start}
 			}
+
+			return Token{token_type, value.String(), lexer.start}
 		}
-	case 'N':
-		return lexer.check_keyword(1, 5, "UMBER", TOKEN_KEYWORD_NUMBER)
-	case 'S':
-		return lexer.check_keyword(1, 5, "ELECT", TOKEN_KEYWORD_SELECT)
-	case 'T':
-		if lexer.index-lexer.start > 1 {
-			char = rune(lexer.source[lexer.start+1])
-			switch to_upper_rune(char) {
-			case 'A':
-				return lexer.check_keyword(2, 3, "BLE", TOKEN_KEYWORD_TABLE)
-			case 'E':
-				return lexer.check_keyword(2, 2, "XT", TOKEN_KEYWORD_TEXT)
-			case 'R':
-				return lexer.check_keyword(2, 2, "UE", TOKEN_KEYWORD_TRUE)
+
+		if char == rune(SYMBOL_BACKSLASH) && escapes {
+			backslash_index := lexer.index
+			if !lexer.lex_escape(&value) && escape_index < 0 {
+				escape_index = backslash_index
 			}
+			continue
 		}
-	case 'V':
-		return lexer.check_keyword(1, 5, "ALUES", TOKEN_KEYWORD_VALUES)
+
+		value.WriteRune(char)
 	}
 
-	token := Token{TOKEN_IDENTIFIER, lexer.source[lexer.start : lexer.index+1], lexer.start}
-	return token
+	lexer.backup()
+
+	if token_type == TOKEN_IDENTIFIER {
+		return Token{TOKEN_ERROR, "Non-terminated quoted identifier", lexer.index}
+	}
+
+	return Token{TOKEN_ERROR, "Non-terminated text literal", lexer.index}
 }
 
-func (lexer *Lexer) check_keyword(start int, length int, suffix string, token_type TokenType) Token {
-	if lexer.index+1-lexer.start == start+length {
-		for i, keyword_char := range suffix {
-			char := to_upper_rune(rune(lexer.source[lexer.start+start+i]))
-			if char != keyword_char {
-				break
-			}
+func (lexer *Lexer) lex_escape(value *strings.Builder) bool {
+	char := lexer.next_rune()
+
+	switch char {
+	case 'b':
+		value.WriteByte('\b')
+	case 'f':
+		value.WriteByte('\f')
+	case 'n':
+		value.WriteByte('\n')
+	case 'r':
+		value.WriteByte('\r')
+	case 't':
+		value.WriteByte('\t')
+	case 'x':
+		code, ok := lexer.lex_escape_code(1, 2)
+		if !ok {
+			return false
+		}
+
+		value.WriteByte(byte(code))
+	case 'u', 'U':
+		digits := 4
+		if char == 'U' {
+			digits = 8
+		}
+
+		code, ok := lexer.lex_escape_code(digits, digits)
+		if !ok || !utf8.ValidRune(code) {
+			return false
+		}
+
+		value.WriteRune(code)
+	case SYMBOL_EOF:
+		return false
+	default:
+		value.WriteRune(char)
+	}
+
+	return true
+}
+
+func (lexer *Lexer) lex_escape_code(min_digits int, max_digits int) (rune, bool) {
+	var code rune
+	digits := 0
+
+	for digits < max_digits {
+		char := lexer.next_rune()
+
+		if !is_hex_digit(char) {
+			lexer.backup()
+			break
 		}
+
+		if is_digit(char) {
+			code = code*16 + char - '0'
+		} else {
+			code = code*16 + to_upper_rune(char) - 'A' + 10
+		}
+		digits += 1
+	}
+
+	return code, digits >= min_digits
+}
+
+func QuoteText(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (lexer *Lexer) lex_keyword_or_identifier() Token {
+	lexer.start = lexer.index
+
+	for {
+		char := lexer.next_rune()
+
+		if !is_alphanumeric(char) {
+			lexer.backup()
+			break
+		}
+	}
+
+	word := lexer.source[lexer.start:lexer.end_index()]
+
+	if token_type, ok := keywords[strings.Map(to_upper_rune, word)]; ok {
 		return Token{token_type, "", lexer.start}
 	}
-	return Token{TOKEN_IDENTIFIER, lexer.source[lexer.start : lexer.index+1], lexer.start}
+
+	return Token{TOKEN_IDENTIFIER, strings.ToLower(word), lexer.start}
 }
@@ -33,8 +33,31 @@ func TestLexSymbol(t *testing.T) {
 	assert.Equal(t, expected, tokens)
 }
 
+func TestLexComparisonOperators(t *testing.T) {
+	tokens := GenerateTokenSlice("= <> != < <= > >= <=>")
+	expected := []Token{
+		{TOKEN_EQUAL, "", 0}, {TOKEN_NOT_EQUAL, "", 2}, {TOKEN_NOT_EQUAL, "", 5},
+		{TOKEN_LESS, "", 8}, {TOKEN_LESS_EQUAL, "", 10}, {TOKEN_GREATER, "", 13},
+		{TOKEN_GREATER_EQUAL, "", 15}, {TOKEN_LESS_EQUAL, "", 18}, {TOKEN_GREATER, "", 20},
+		{TOKEN_EOF, "", 21},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexLogicalKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("NULL is Not and OR where")
+	expected := []Token{
+		{TOKEN_KEYWORD_NULL, "", 0}, {TOKEN_KEYWORD_IS, "", 5}, {TOKEN_KEYWORD_NOT, "", 8},
+		{TOKEN_KEYWORD_AND, "", 12}, {TOKEN_KEYWORD_OR, "", 16}, {TOKEN_KEYWORD_WHERE, "", 19},
+		{TOKEN_EOF, "", 24},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
 func TestLexSymbolErrors(t *testing.T) {
-	tokens := GenerateTokenSlice("! / $")
+	tokens := GenerateTokenSlice("! | $")
 	expected := []Token{
 		{TOKEN_ERROR, "Unidentified token", 0}, {TOKEN_ERROR, "Unidentified token", 2}, {TOKEN_ERROR, "Unidentified token", 4},
 		{TOKEN_EOF, "", 5},
@@ -43,12 +66,81 @@ func TestLexSymbolErrors(t *testing.T) {
 	assert.Equal(t, expected, tokens)
 }
 
+func TestLexLineComment(t *testing.T) {
+	tokens := GenerateTokenSlice("-- leading\nSELECT -- trailing ;\n*--\n-- end")
+	expected := []Token{
+		{TOKEN_KEYWORD_SELECT, "", 11}, {TOKEN_ASTERISK, "", 32}, {TOKEN_EOF, "", 42},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexBlockComment(t *testing.T) {
+	tokens := GenerateTokenSlice("/**/SELECT /* multi\nline; 'text' -- */ * /*/ nested /* */,")
+	expected := []Token{
+		{TOKEN_KEYWORD_SELECT, "", 4}, {TOKEN_ASTERISK, "", 39}, {TOKEN_COMMA, "", 57}, {TOKEN_EOF, "", 58},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexCommentErrors(t *testing.T) {
+	tokens := GenerateTokenSlice("- ; /* open")
+	expected := []Token{
+		{TOKEN_MINUS, "", 0}, {TOKEN_SEMI_COLON, "", 2},
+		{TOKEN_ERROR, "Non-terminated block comment", 4}, {TOKEN_EOF, "", 11},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
 func TestLexNumber(t *testing.T) {
 	tokens := GenerateTokenSlice("1 2.34 500 06 07.80 .9 1.")
 	expected := []Token{
-		{TOKEN_LITERAL_NUMBER, "1", 0}, {TOKEN_LITERAL_NUMBER, "2.34", 2}, {TOKEN_LITERAL_NUMBER, "500", 7},
-		{TOKEN_LITERAL_NUMBER, "06", 11}, {TOKEN_LITERAL_NUMBER, "07.80", 14}, {TOKEN_LITERAL_NUMBER, ".9", 20},
-		{TOKEN_LITERAL_NUMBER, "1.", 23}, {TOKEN_EOF, "", 25},
+		{TOKEN_LITERAL_INTEGER, "1", 0}, {TOKEN_LITERAL_REAL, "2.34", 2}, {TOKEN_LITERAL_INTEGER, "500", 7},
+		{TOKEN_LITERAL_INTEGER, "06", 11}, {TOKEN_LITERAL_REAL, "07.80", 14}, {TOKEN_LITERAL_REAL, ".9", 20},
+		{TOKEN_LITERAL_REAL, "1.", 23}, {TOKEN_EOF, "", 25},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexNumberExponent(t *testing.T) {
+	tokens := GenerateTokenSlice("1.5e-3 2E10 3e+2 .5e1 7.e0")
+	expected := []Token{
+		{TOKEN_LITERAL_REAL, "1.5e-3", 0}, {TOKEN_LITERAL_REAL, "2e10", 7}, {TOKEN_LITERAL_REAL, "3e+2", 12},
+		{TOKEN_LITERAL_REAL, ".5e1", 17}, {TOKEN_LITERAL_REAL, "7.e0", 22}, {TOKEN_EOF, "", 26},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexNumberHex(t *testing.T) {
+	tokens := GenerateTokenSlice("0x1F 0Xff 0x0 0xDEAD_BEEF")
+	expected := []Token{
+		{TOKEN_LITERAL_INTEGER, "0x1F", 0}, {TOKEN_LITERAL_INTEGER, "0xff", 5}, {TOKEN_LITERAL_INTEGER, "0x0", 10},
+		{TOKEN_LITERAL_INTEGER, "0xDEADBEEF", 14}, {TOKEN_EOF, "", 25},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexNumberUnderscores(t *testing.T) {
+	tokens := GenerateTokenSlice("1_000_000 3.141_592 1_0e1_0")
+	expected := []Token{
+		{TOKEN_LITERAL_INTEGER, "1000000", 0}, {TOKEN_LITERAL_REAL, "3.141592", 10},
+		{TOKEN_LITERAL_REAL, "10e10", 20}, {TOKEN_EOF, "", 27},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexNumberSigns(t *testing.T) {
+	tokens := GenerateTokenSlice("-1 +2.5 - -3")
+	expected := []Token{
+		{TOKEN_MINUS, "", 0}, {TOKEN_LITERAL_INTEGER, "1", 1}, {TOKEN_PLUS, "", 3},
+		{TOKEN_LITERAL_REAL, "2.5", 4}, {TOKEN_MINUS, "", 8}, {TOKEN_MINUS, "", 10},
+		{TOKEN_LITERAL_INTEGER, "3", 11}, {TOKEN_EOF, "", 12},
 	}
 
 	assert.Equal(t, expected, tokens)
@@ -57,7 +149,20 @@ func TestLexNumber(t *testing.T) {
 func TestLexNumberErrors(t *testing.T) {
 	tokens := GenerateTokenSlice(". 3..4")
 	expected := []Token{
-		{TOKEN_ERROR, "Invalid number literal", 0}, {TOKEN_ERROR, "Invalid number literal", 2}, {TOKEN_EOF, "", 6},
+		{TOKEN_DOT, "", 0}, {TOKEN_ERROR, "Invalid number literal", 2}, {TOKEN_EOF, "", 6},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexNumberSyntaxErrors(t *testing.T) {
+	tokens := GenerateTokenSlice("1e 2e+ 0x 0x1G 1__0 2_ 3._1 4abc ;")
+	expected := []Token{
+		{TOKEN_ERROR, "Invalid number literal", 0}, {TOKEN_ERROR, "Invalid number literal", 3},
+		{TOKEN_ERROR, "Invalid number literal", 7}, {TOKEN_ERROR, "Invalid number literal", 10},
+		{TOKEN_ERROR, "Invalid number literal", 15}, {TOKEN_ERROR, "Invalid number literal", 20},
+		{TOKEN_ERROR, "Invalid number literal", 23}, {TOKEN_ERROR, "Invalid number literal", 28},
+		{TOKEN_SEMI_COLON, "", 33}, {TOKEN_EOF, "", 34},
 	}
 
 	assert.Equal(t, expected, tokens)
@@ -74,6 +179,47 @@ func TestLexText(t *testing.T) {
 	assert.Equal(t, expected, tokens)
 }
 
+func TestLexTextDoubledQuotes(t *testing.T) {
+	tokens := GenerateTokenSlice("'it''s' '''' 'a''''b' ''")
+	expected := []Token{
+		{TOKEN_LITERAL_TEXT, "it's", 0}, {TOKEN_LITERAL_TEXT, "'", 8},
+		{TOKEN_LITERAL_TEXT, "a''b", 13}, {TOKEN_LITERAL_TEXT, "", 22},
+		{TOKEN_EOF, "", 24},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexEscapedText(t *testing.T) {
+	tokens := GenerateTokenSlice(`E'a\nb\t\\' e'it\'s''' E'\x41é\U0001F642\q' E 'x'`)
+	expected := []Token{
+		{TOKEN_LITERAL_TEXT, "a\nb\t\\", 0}, {TOKEN_LITERAL_TEXT, "it's'", 12},
+		{TOKEN_LITERAL_TEXT, "Aé🙂q", 23}, {TOKEN_IDENTIFIER, "e", 45},
+		{TOKEN_LITERAL_TEXT, "x", 47}, {TOKEN_EOF, "", 50},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexEscapedTextErrors(t *testing.T) {
+	tokens := GenerateTokenSlice(`E'ok\u12' E'\xff' E'\`)
+	expected := []Token{
+		{TOKEN_ERROR, "Invalid escape sequence", 4}, {TOKEN_ERROR, "Invalid UTF-8 sequence", 10},
+		{TOKEN_ERROR, "Non-terminated text literal", 20}, {TOKEN_EOF, "", 21},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestQuoteText(t *testing.T) {
+	assert.Equal(t, "''", QuoteText(""))
+	assert.Equal(t, "'it''s'", QuoteText("it's"))
+	assert.Equal(t, "''''''", QuoteText("''"))
+
+	tokens := GenerateTokenSlice(QuoteText("a'b''c"))
+	assert.Equal(t, []Token{{TOKEN_LITERAL_TEXT, "a'b''c", 0}, {TOKEN_EOF, "", 11}}, tokens)
+}
+
 func TestLexTextErrors(t *testing.T) {
 	tokens := GenerateTokenSlice("'abcd")
 	expected := []Token{{TOKEN_ERROR, "Non-terminated text literal", 4}, {TOKEN_EOF, "", 5}}
@@ -120,18 +266,89 @@ func TestLexKeywordMixed(t *testing.T) {
 	assert.Equal(t, expected, tokens)
 }
 
+func TestLexTypeKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("INTEGER real Decimal BLOB date TIME timestamp Interval")
+	expected := []Token{
+		{TOKEN_KEYWORD_INTEGER, "", 0}, {TOKEN_KEYWORD_REAL, "", 8}, {TOKEN_KEYWORD_DECIMAL, "", 13},
+		{TOKEN_KEYWORD_BLOB, "", 21}, {TOKEN_KEYWORD_DATE, "", 26}, {TOKEN_KEYWORD_TIME, "", 31},
+		{TOKEN_KEYWORD_TIMESTAMP, "", 36}, {TOKEN_KEYWORD_INTERVAL, "", 46}, {TOKEN_EOF, "", 54},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexBlob(t *testing.T) {
+	tokens := GenerateTokenSlice("X'' x'00ff' X'DEADbeef' x 'ab'")
+	expected := []Token{
+		{TOKEN_LITERAL_BLOB, "", 0}, {TOKEN_LITERAL_BLOB, "00ff", 4}, {TOKEN_LITERAL_BLOB, "DEADbeef", 12},
+		{TOKEN_IDENTIFIER, "x", 24}, {TOKEN_LITERAL_TEXT, "ab", 26}, {TOKEN_EOF, "", 30},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexBlobErrors(t *testing.T) {
+	tokens := GenerateTokenSlice("X'abc' X'zz' X'00")
+	expected := []Token{
+		{TOKEN_ERROR, "Invalid blob literal", 0}, {TOKEN_ERROR, "Invalid blob literal", 7},
+		{TOKEN_ERROR, "Non-terminated text literal", 16}, {TOKEN_EOF, "", 17},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
 func TestLexIdentifiers(t *testing.T) {
-	tokens := GenerateTokenSlice("table_1 column_2_b TABLE_3 false4 tabl tabler")
+	tokens := GenerateTokenSlice("table_1 column_2_b TABLE_3 false4 tabl tabler tablx")
 	expected := []Token{
 		{TOKEN_IDENTIFIER, "table_1", 0}, {TOKEN_IDENTIFIER, "column_2_b", 8},
-		{TOKEN_IDENTIFIER, "TABLE_3", 19}, {TOKEN_IDENTIFIER, "false4", 27},
+		{TOKEN_IDENTIFIER, "table_3", 19}, {TOKEN_IDENTIFIER, "false4", 27},
 		{TOKEN_IDENTIFIER, "tabl", 34}, {TOKEN_IDENTIFIER, "tabler", 39},
-		{TOKEN_EOF, "", 45},
+		{TOKEN_IDENTIFIER, "tablx", 46}, {TOKEN_EOF, "", 51},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexIdentifiersFolded(t *testing.T) {
+	tokens := GenerateTokenSlice("Users USERS ÉCOLE")
+	expected := []Token{
+		{TOKEN_IDENTIFIER, "users", 0}, {TOKEN_IDENTIFIER, "users", 6},
+		{TOKEN_IDENTIFIER, "école", 12}, {TOKEN_EOF, "", 18},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexQuotedIdentifiers(t *testing.T) {
+	tokens := GenerateTokenSlice("\"select\" \"Text\" `Mixed Case` \"a\"\"b\" `x``y` \"日付\"")
+	expected := []Token{
+		{TOKEN_IDENTIFIER, "select", 0}, {TOKEN_IDENTIFIER, "Text", 9},
+		{TOKEN_IDENTIFIER, "Mixed Case", 16}, {TOKEN_IDENTIFIER, "a\"b", 29},
+		{TOKEN_IDENTIFIER, "x`y", 36}, {TOKEN_IDENTIFIER, "日付", 43},
+		{TOKEN_EOF, "", 51},
 	}
 
 	assert.Equal(t, expected, tokens)
 }
 
+func TestLexQuotedIdentifierErrors(t *testing.T) {
+	tokens := GenerateTokenSlice("\"\" `a\xff` \"open")
+	expected := []Token{
+		{TOKEN_ERROR, "Zero-length quoted identifier", 0}, {TOKEN_ERROR, "Invalid UTF-8 sequence", 5},
+		{TOKEN_ERROR, "Non-terminated quoted identifier", 12}, {TOKEN_EOF, "", 13},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, `"select"`, QuoteIdentifier("select"))
+	assert.Equal(t, `"a""b"`, QuoteIdentifier(`a"b`))
+
+	tokens := GenerateTokenSlice(QuoteIdentifier(`My "Col"`))
+	assert.Equal(t, []Token{{TOKEN_IDENTIFIER, `My "Col"`, 0}, {TOKEN_EOF, "", 12}}, tokens)
+}
+
 func TestLexCreateTable(t *testing.T) {
 	tokens := GenerateTokenSlice("CREATE TABLE t (c_1 NUMBER, c_2 TEXT);")
 	expected := []Token{
@@ -149,7 +366,7 @@ func TestLexInsertInto(t *testing.T) {
 	expected := []Token{
 		{TOKEN_KEYWORD_INSERT, "", 0}, {TOKEN_KEYWORD_INTO, "", 7}, {TOKEN_IDENTIFIER, "t", 12},
 		{TOKEN_KEYWORD_VALUES, "", 14}, {TOKEN_LEFT_PAREN, "", 21}, {TOKEN_IDENTIFIER, "c_1", 22},
-		{TOKEN_LITERAL_NUMBER, "10.5", 26}, {TOKEN_COMMA, "", 30}, {TOKEN_IDENTIFIER, "c_2", 32},
+		{TOKEN_LITERAL_REAL, "10.5", 26}, {TOKEN_COMMA, "", 30}, {TOKEN_IDENTIFIER, "c_2", 32},
 		{TOKEN_LITERAL_TEXT, "Hello $ % !", 36}, {TOKEN_RIGHT_PAREN, "", 49}, {TOKEN_SEMI_COLON, "", 50},
 		{TOKEN_EOF, "", 51},
 	}
@@ -180,7 +397,257 @@ func TestLexSelectStar(t *testing.T) {
 
 func TestLexNonUtf8(t *testing.T) {
 	tokens := GenerateTokenSlice("\xc5")
-	expected := []Token{{TOKEN_ERROR, "Unidentified token", 0}, {TOKEN_EOF, "", 1}}
+	expected := []Token{{TOKEN_ERROR, "Invalid UTF-8 sequence", 0}, {TOKEN_EOF, "", 1}}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexNonUtf8Text(t *testing.T) {
+	tokens := GenerateTokenSlice("'ab\xe2\x82c' 'é'")
+	expected := []Token{
+		{TOKEN_ERROR, "Invalid UTF-8 sequence", 3}, {TOKEN_LITERAL_TEXT, "é", 8}, {TOKEN_EOF, "", 12},
+	}
 
 	assert.Equal(t, expected, tokens)
 }
+
+func TestLexUtf8Text(t *testing.T) {
+	tokens := GenerateTokenSlice("'héllo' '日本語' '🙂' ''")
+	expected := []Token{
+		{TOKEN_LITERAL_TEXT, "héllo", 0}, {TOKEN_LITERAL_TEXT, "日本語", 9},
+		{TOKEN_LITERAL_TEXT, "🙂", 21}, {TOKEN_LITERAL_TEXT, "", 28}, {TOKEN_EOF, "", 30},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexUtf8Identifiers(t *testing.T) {
+	tokens := GenerateTokenSlice("naïve café_2 日付 ταβλε tablé e\u0301t")
+	expected := []Token{
+		{TOKEN_IDENTIFIER, "naïve", 0}, {TOKEN_IDENTIFIER, "café_2", 7},
+		{TOKEN_IDENTIFIER, "日付", 15}, {TOKEN_IDENTIFIER, "ταβλε", 22},
+		{TOKEN_IDENTIFIER, "tablé", 33}, {TOKEN_IDENTIFIER, "e\u0301t", 40},
+		{TOKEN_EOF, "", 44},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexUtf8Statement(t *testing.T) {
+	tokens := GenerateTokenSlice("INSERT INTO città VALUES ('Zürich', 1.5);")
+	expected := []Token{
+		{TOKEN_KEYWORD_INSERT, "", 0}, {TOKEN_KEYWORD_INTO, "", 7}, {TOKEN_IDENTIFIER, "città", 12},
+		{TOKEN_KEYWORD_VALUES, "", 19}, {TOKEN_LEFT_PAREN, "", 26}, {TOKEN_LITERAL_TEXT, "Zürich", 27},
+		{TOKEN_COMMA, "", 36}, {TOKEN_LITERAL_REAL, "1.5", 38}, {TOKEN_RIGHT_PAREN, "", 41},
+		{TOKEN_SEMI_COLON, "", 42}, {TOKEN_EOF, "", 43},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexUnicodeSymbolErrors(t *testing.T) {
+	tokens := GenerateTokenSlice("→ 1")
+	expected := []Token{{TOKEN_ERROR, "Unidentified token", 0}, {TOKEN_LITERAL_INTEGER, "1", 4}, {TOKEN_EOF, "", 5}}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexConstraintKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("constraint Primary KEY unique default check")
+	expected := []Token{
+		{TOKEN_KEYWORD_CONSTRAINT, "", 0}, {TOKEN_KEYWORD_PRIMARY, "", 11}, {TOKEN_KEYWORD_KEY, "", 19},
+		{TOKEN_KEYWORD_UNIQUE, "", 23}, {TOKEN_KEYWORD_DEFAULT, "", 30}, {TOKEN_KEYWORD_CHECK, "", 38},
+		{TOKEN_EOF, "", 43},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexForeignKeyKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("FOREIGN REFERENCES on delete update cascade restrict set no action deferrable initially deferred immediate")
+	expected := []Token{
+		{TOKEN_KEYWORD_FOREIGN, "", 0}, {TOKEN_KEYWORD_REFERENCES, "", 8}, {TOKEN_KEYWORD_ON, "", 19},
+		{TOKEN_KEYWORD_DELETE, "", 22}, {TOKEN_KEYWORD_UPDATE, "", 29}, {TOKEN_KEYWORD_CASCADE, "", 36},
+		{TOKEN_KEYWORD_RESTRICT, "", 44}, {TOKEN_KEYWORD_SET, "", 53}, {TOKEN_KEYWORD_NO, "", 57},
+		{TOKEN_KEYWORD_ACTION, "", 60}, {TOKEN_KEYWORD_DEFERRABLE, "", 67}, {TOKEN_KEYWORD_INITIALLY, "", 78},
+		{TOKEN_KEYWORD_DEFERRED, "", 88}, {TOKEN_KEYWORD_IMMEDIATE, "", 97},
+		{TOKEN_EOF, "", 106},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexIdentityKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("autoincrement generated always by as identity returning")
+	expected := []Token{
+		{TOKEN_KEYWORD_AUTOINCREMENT, "", 0}, {TOKEN_KEYWORD_GENERATED, "", 14}, {TOKEN_KEYWORD_ALWAYS, "", 24},
+		{TOKEN_KEYWORD_BY, "", 31}, {TOKEN_KEYWORD_AS, "", 34}, {TOKEN_KEYWORD_IDENTITY, "", 37},
+		{TOKEN_KEYWORD_RETURNING, "", 46},
+		{TOKEN_EOF, "", 55},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexQualifiedName(t *testing.T) {
+	tokens := GenerateTokenSlice("excluded.a t .5 \"T\".b")
+	expected := []Token{
+		{TOKEN_IDENTIFIER, "excluded", 0}, {TOKEN_DOT, "", 8}, {TOKEN_IDENTIFIER, "a", 9},
+		{TOKEN_IDENTIFIER, "t", 11}, {TOKEN_LITERAL_REAL, ".5", 13},
+		{TOKEN_IDENTIFIER, "T", 16}, {TOKEN_DOT, "", 19}, {TOKEN_IDENTIFIER, "b", 20},
+		{TOKEN_EOF, "", 21},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexConflictKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("conflict DO nothing")
+	expected := []Token{
+		{TOKEN_KEYWORD_CONFLICT, "", 0}, {TOKEN_KEYWORD_DO, "", 9}, {TOKEN_KEYWORD_NOTHING, "", 12},
+		{TOKEN_EOF, "", 19},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexParameters(t *testing.T) {
+	tokens := GenerateTokenSlice("? $1 $23,$4x $")
+	expected := []Token{
+		{TOKEN_PARAMETER, "", 0}, {TOKEN_PARAMETER, "1", 2}, {TOKEN_PARAMETER, "23", 5}, {TOKEN_COMMA, "", 8},
+		{TOKEN_ERROR, "Invalid parameter", 9}, {TOKEN_ERROR, "Unidentified token", 13},
+		{TOKEN_EOF, "", 14},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexOrderingKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("ORDER asc Desc nulls first last limit offset")
+	expected := []Token{
+		{TOKEN_KEYWORD_ORDER, "", 0}, {TOKEN_KEYWORD_ASC, "", 6}, {TOKEN_KEYWORD_DESC, "", 10},
+		{TOKEN_KEYWORD_NULLS, "", 15}, {TOKEN_KEYWORD_FIRST, "", 21}, {TOKEN_KEYWORD_LAST, "", 27},
+		{TOKEN_KEYWORD_LIMIT, "", 32}, {TOKEN_KEYWORD_OFFSET, "", 38},
+		{TOKEN_EOF, "", 44},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexGroupingKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("distinct ALL group having")
+	expected := []Token{
+		{TOKEN_KEYWORD_DISTINCT, "", 0}, {TOKEN_KEYWORD_ALL, "", 9}, {TOKEN_KEYWORD_GROUP, "", 13},
+		{TOKEN_KEYWORD_HAVING, "", 19},
+		{TOKEN_EOF, "", 25},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexJoinKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("join INNER left right full outer cross using")
+	expected := []Token{
+		{TOKEN_KEYWORD_JOIN, "", 0}, {TOKEN_KEYWORD_INNER, "", 5}, {TOKEN_KEYWORD_LEFT, "", 11},
+		{TOKEN_KEYWORD_RIGHT, "", 16}, {TOKEN_KEYWORD_FULL, "", 22}, {TOKEN_KEYWORD_OUTER, "", 27},
+		{TOKEN_KEYWORD_CROSS, "", 33}, {TOKEN_KEYWORD_USING, "", 39},
+		{TOKEN_EOF, "", 44},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexSubqueryKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("exists IN")
+	expected := []Token{
+		{TOKEN_KEYWORD_EXISTS, "", 0}, {TOKEN_KEYWORD_IN, "", 7},
+		{TOKEN_EOF, "", 9},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexSetOperationKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("union Intersect EXCEPT")
+	expected := []Token{
+		{TOKEN_KEYWORD_UNION, "", 0}, {TOKEN_KEYWORD_INTERSECT, "", 6}, {TOKEN_KEYWORD_EXCEPT, "", 16},
+		{TOKEN_EOF, "", 22},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexCommonTableKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("WITH recursive")
+	expected := []Token{
+		{TOKEN_KEYWORD_WITH, "", 0}, {TOKEN_KEYWORD_RECURSIVE, "", 5},
+		{TOKEN_EOF, "", 14},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexArithmeticOperators(t *testing.T) {
+	tokens := GenerateTokenSlice("a+b-c*d/e%f||g")
+	expected := []Token{
+		{TOKEN_IDENTIFIER, "a", 0}, {TOKEN_PLUS, "", 1}, {TOKEN_IDENTIFIER, "b", 2}, {TOKEN_MINUS, "", 3},
+		{TOKEN_IDENTIFIER, "c", 4}, {TOKEN_ASTERISK, "", 5}, {TOKEN_IDENTIFIER, "d", 6}, {TOKEN_SLASH, "", 7},
+		{TOKEN_IDENTIFIER, "e", 8}, {TOKEN_PERCENT, "", 9}, {TOKEN_IDENTIFIER, "f", 10}, {TOKEN_CONCAT, "", 11},
+		{TOKEN_IDENTIFIER, "g", 13},
+		{TOKEN_EOF, "", 14},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexConditionalKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("CASE when Then ELSE end cast")
+	expected := []Token{
+		{TOKEN_KEYWORD_CASE, "", 0}, {TOKEN_KEYWORD_WHEN, "", 5}, {TOKEN_KEYWORD_THEN, "", 10},
+		{TOKEN_KEYWORD_ELSE, "", 15}, {TOKEN_KEYWORD_END, "", 20}, {TOKEN_KEYWORD_CAST, "", 24},
+		{TOKEN_EOF, "", 28},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexPatternKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("LIKE ilike GLOB regexp ESCAPE between")
+	expected := []Token{
+		{TOKEN_KEYWORD_LIKE, "", 0}, {TOKEN_KEYWORD_ILIKE, "", 5}, {TOKEN_KEYWORD_GLOB, "", 11},
+		{TOKEN_KEYWORD_REGEXP, "", 16}, {TOKEN_KEYWORD_ESCAPE, "", 23}, {TOKEN_KEYWORD_BETWEEN, "", 30},
+		{TOKEN_EOF, "", 37},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexWindowKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("OVER partition ROWS range UNBOUNDED preceding FOLLOWING current ROW")
+	expected := []Token{
+		{TOKEN_KEYWORD_OVER, "", 0}, {TOKEN_KEYWORD_PARTITION, "", 5}, {TOKEN_KEYWORD_ROWS, "", 15},
+		{TOKEN_KEYWORD_RANGE, "", 20}, {TOKEN_KEYWORD_UNBOUNDED, "", 26}, {TOKEN_KEYWORD_PRECEDING, "", 36},
+		{TOKEN_KEYWORD_FOLLOWING, "", 46}, {TOKEN_KEYWORD_CURRENT, "", 56}, {TOKEN_KEYWORD_ROW, "", 64},
+		{TOKEN_EOF, "", 67},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestLexIndexKeywords(t *testing.T) {
+	tokens := GenerateTokenSlice("CREATE index DROP")
+	expected := []Token{
+		{TOKEN_KEYWORD_CREATE, "", 0}, {TOKEN_KEYWORD_INDEX, "", 7}, {TOKEN_KEYWORD_DROP, "", 13},
+		{TOKEN_EOF, "", 17},
+	}
+
+	assert.Equal(t, expected, tokens)
+}
+
+func TestUnreservedKeywordAsIdentifier(t *testing.T) {
+	tokens := GenerateTokenSlice("Date select")
+
+	assert.True(t, tokens[0].IsUnreserved())
+	assert.Equal(t, MakeToken(TOKEN_IDENTIFIER, "date", 0), tokens[0].AsIdentifier())
+	assert.False(t, tokens[1].IsUnreserved())
+}
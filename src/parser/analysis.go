@@ -0,0 +1,504 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/JamesErrington/tasiadb/src/functions"
+	lex "github.com/JamesErrington/tasiadb/src/lexer"
+)
+
+func walk_expression(expression Expression, visit func(Expression) bool) {
+	if expression == nil || !visit(expression) {
+		return
+	}
+
+	switch e := expression.(type) {
+	case *UnaryExpression:
+		walk_expression(e.operand, visit)
+	case *BinaryExpression:
+		walk_expression(e.left, visit)
+		walk_expression(e.right, visit)
+	case *IsNullExpression:
+		walk_expression(e.operand, visit)
+	case *FunctionExpression:
+		for _, argument := range e.arguments {
+			walk_expression(argument, visit)
+		}
+	case *WindowExpression:
+		for _, argument := range e.function.arguments {
+			walk_expression(argument, visit)
+		}
+		for _, expression := range e.window.partition_by {
+			walk_expression(expression, visit)
+		}
+		for _, term := range e.window.order_by {
+			walk_expression(term.expression, visit)
+		}
+	case *InExpression:
+		walk_expression(e.operand, visit)
+		for _, value := range e.values {
+			walk_expression(value, visit)
+		}
+	case *PatternExpression:
+		walk_expression(e.operand, visit)
+		walk_expression(e.pattern, visit)
+		walk_expression(e.escape, visit)
+	case *BetweenExpression:
+		walk_expression(e.operand, visit)
+		walk_expression(e.low, visit)
+		walk_expression(e.high, visit)
+	case *CaseExpression:
+		walk_expression(e.operand, visit)
+		for _, when := range e.whens {
+			walk_expression(when.condition, visit)
+			walk_expression(when.result, visit)
+		}
+		walk_expression(e.else_result, visit)
+	case *CastExpression:
+		walk_expression(e.operand, visit)
+	}
+}
+
+func same_expression(left Expression, right Expression, tables map[string]bool) bool {
+	switch l := left.(type) {
+	case *LiteralExpression:
+		r, ok := right.(*LiteralExpression)
+		return ok && l._type == r._type && same_token(l.value, r.value)
+	case *ParameterExpression:
+		r, ok := right.(*ParameterExpression)
+		return ok && l.index == r.index
+	case *ColumnExpression:
+		r, ok := right.(*ColumnExpression)
+		return ok && same_token(l.name, r.name) && same_qualifier(l.table, r.table, tables)
+	case *StarExpression:
+		r, ok := right.(*StarExpression)
+		return ok && same_token(l.table, r.table)
+	case *UnaryExpression:
+		r, ok := right.(*UnaryExpression)
+		return ok && same_token(l.operator, r.operator) && same_expression(l.operand, r.operand, tables)
+	case *BinaryExpression:
+		r, ok := right.(*BinaryExpression)
+		return ok && same_token(l.operator, r.operator) && same_expression(l.left, r.left, tables) && same_expression(l.right, r.right, tables)
+	case *IsNullExpression:
+		r, ok := right.(*IsNullExpression)
+		return ok && l.negated == r.negated && same_expression(l.operand, r.operand, tables)
+	case *FunctionExpression:
+		r, ok := right.(*FunctionExpression)
+		return ok && same_token(l.name, r.name) && l.distinct == r.distinct && l.star == r.star && same_expressions(l.arguments, r.arguments, tables)
+	case *CaseExpression:
+		r, ok := right.(*CaseExpression)
+		if !ok || len(l.whens) != len(r.whens) || !same_optional_expression(l.operand, r.operand, tables) || !same_optional_expression(l.else_result, r.else_result, tables) {
+			return false
+		}
+
+		for i := range l.whens {
+			if !same_expression(l.whens[i].condition, r.whens[i].condition, tables) || !same_expression(l.whens[i].result, r.whens[i].result, tables) {
+				return false
+			}
+		}
+
+		return true
+	case *CastExpression:
+		r, ok := right.(*CastExpression)
+		return ok && same_expression(l.operand, r.operand, tables) && same_data_type(l.data_type, r.data_type)
+	case *InExpression:
+		r, ok := right.(*InExpression)
+		return ok && l.query == nil && r.query == nil && l.negated == r.negated && same_expression(l.operand, r.operand, tables) && same_expressions(l.values, r.values, tables)
+	case *PatternExpression:
+		r, ok := right.(*PatternExpression)
+		return ok && same_token(l.operator, r.operator) && l.negated == r.negated && same_expression(l.operand, r.operand, tables) &&
+			same_expression(l.pattern, r.pattern, tables) && same_optional_expression(l.escape, r.escape, tables)
+	case *BetweenExpression:
+		r, ok := right.(*BetweenExpression)
+		return ok && l.negated == r.negated && same_expression(l.operand, r.operand, tables) && same_expression(l.low, r.low, tables) && same_expression(l.high, r.high, tables)
+	}
+
+	return false
+}
+
+func same_optional_expression(left Expression, right Expression, tables map[string]bool) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+
+	return same_expression(left, right, tables)
+}
+
+// An unqualified column matches a qualified one when the qualifier names a table in FROM.
+func same_qualifier(left lex.Token, right lex.Token, tables map[string]bool) bool {
+	switch {
+	case !left.IsTokenType(lex.TOKEN_IDENTIFIER):
+		return !right.IsTokenType(lex.TOKEN_IDENTIFIER) || tables[right.Value()]
+	case !right.IsTokenType(lex.TOKEN_IDENTIFIER):
+		return tables[left.Value()]
+	}
+
+	return left.Value() == right.Value()
+}
+
+func same_data_type(left DataType, right DataType) bool {
+	if !same_token(left.name, right.name) || len(left.parameters) != len(right.parameters) {
+		return false
+	}
+
+	for i := range left.parameters {
+		if !same_token(left.parameters[i], right.parameters[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func same_expressions(left []Expression, right []Expression, tables map[string]bool) bool {
+	if len(left) != len(right) {
+		return false
+	}
+
+	for i := range left {
+		if !same_expression(left[i], right[i], tables) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func same_token(left lex.Token, right lex.Token) bool {
+	return left.Type() == right.Type() && left.Value() == right.Value()
+}
+
+func is_aggregate(expression Expression) bool {
+	function, ok := expression.(*FunctionExpression)
+	return ok && functions.IsAggregate(function.name.Value())
+}
+
+func contains_aggregate(expression Expression) bool {
+	found := false
+	walk_expression(expression, func(e Expression) bool {
+		found = found || is_aggregate(e)
+		return !found
+	})
+
+	return found
+}
+
+func check_no_aggregates(expression Expression, clause string) {
+	if contains_aggregate(expression) {
+		panic(fmt.Sprintf("Aggregate functions are not allowed in %s", clause))
+	}
+}
+
+func contains_window(expression Expression) bool {
+	found := false
+	walk_expression(expression, func(e Expression) bool {
+		_, window := e.(*WindowExpression)
+		found = found || window
+		return !found
+	})
+
+	return found
+}
+
+func check_no_windows(expression Expression, clause string) {
+	if contains_window(expression) {
+		panic(fmt.Sprintf("Window functions are not allowed in %s", clause))
+	}
+}
+
+func check_plain_expression(expression Expression, clause string) {
+	check_no_aggregates(expression, clause)
+	check_no_windows(expression, clause)
+}
+
+func check_query(query Query) {
+	switch content := query.(type) {
+	case *SelectStatement:
+		check_aggregates(content)
+	case *CompoundSelect:
+		check_query(content.left)
+		check_query(content.right)
+	}
+}
+
+func query_width(query Query) (int, bool) {
+	switch content := query.(type) {
+	case *SelectStatement:
+		for _, column := range content.columns {
+			if _, ok := column.expression.(*StarExpression); ok {
+				return 0, false
+			}
+		}
+
+		return len(content.columns), true
+	case *CompoundSelect:
+		return query_width(content.left)
+	case *WithQuery:
+		return query_width(content.query)
+	}
+
+	return 0, false
+}
+
+func check_common_table(table CommonTable, recursive bool) {
+	name := table.name.Value()
+
+	if width, ok := query_width(table.query); ok && table.columns != nil && width != len(table.columns) {
+		panic(fmt.Sprintf("WITH query %s has %d columns available but %d columns specified", lex.QuoteIdentifier(name), width, len(table.columns)))
+	}
+
+	if !recursive || !references_table(table.query, name) {
+		return
+	}
+
+	compound, ok := table.query.(*CompoundSelect)
+	if !ok || compound.operator != SET_UNION || references_table(compound.left, name) {
+		panic(fmt.Sprintf("Recursive query %s does not have the form non-recursive-term UNION [ALL] recursive-term", lex.QuoteIdentifier(name)))
+	}
+}
+
+func references_table(query Query, name string) bool {
+	switch content := query.(type) {
+	case *SelectStatement:
+		if table_reference_uses(content.from, name) {
+			return true
+		}
+
+		for _, expression := range content.expressions() {
+			if expression_references_table(expression, name) {
+				return true
+			}
+		}
+
+		return false
+	case *CompoundSelect:
+		return references_table(content.left, name) || references_table(content.right, name)
+	case *WithQuery:
+		for _, table := range content.tables {
+			if references_table(table.query, name) {
+				return true
+			}
+		}
+
+		return references_table(content.query, name)
+	}
+
+	return false
+}
+
+func table_reference_uses(from TableReference, name string) bool {
+	switch table := from.(type) {
+	case *TableName:
+		return table.name.Value() == name
+	case *SubqueryTable:
+		return references_table(table.query, name)
+	case *JoinClause:
+		return table_reference_uses(table.left, name) || table_reference_uses(table.right, name) || expression_references_table(table.on, name)
+	}
+
+	return false
+}
+
+func expression_references_table(expression Expression, name string) bool {
+	found := false
+	walk_expression(expression, func(e Expression) bool {
+		switch e := e.(type) {
+		case *SubqueryExpression:
+			found = found || references_table(e.query, name)
+		case *ExistsExpression:
+			found = found || references_table(e.query, name)
+		case *InExpression:
+			found = found || (e.query != nil && references_table(e.query, name))
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func check_aggregates(content *SelectStatement) {
+	for _, expression := range content.group_by {
+		check_no_aggregates(expression, "GROUP BY")
+		check_no_windows(expression, "GROUP BY")
+	}
+	check_no_windows(content.having, "HAVING")
+
+	for _, expression := range content.output_expressions() {
+		walk_expression(expression, func(e Expression) bool {
+			if window, ok := e.(*WindowExpression); ok {
+				for _, argument := range window.function.arguments {
+					if contains_window(argument) {
+						panic("Window function calls cannot be nested")
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	grouped := content.group_by != nil || content.having != nil
+
+	for _, expression := range content.output_expressions() {
+		walk_expression(expression, func(e Expression) bool {
+			if !is_aggregate(e) {
+				return true
+			}
+
+			for _, argument := range e.(*FunctionExpression).arguments {
+				if contains_aggregate(argument) {
+					panic("Aggregate function calls cannot be nested")
+				}
+
+				if contains_window(argument) {
+					panic("Aggregate function calls cannot contain window function calls")
+				}
+			}
+
+			grouped = true
+			return false
+		})
+	}
+
+	if !grouped {
+		return
+	}
+
+	tables := make(map[string]bool)
+	check_table_names(content.from, tables)
+
+	for _, expression := range content.output_expressions() {
+		check_grouped(expression, content.group_by, tables)
+	}
+}
+
+func check_grouped(expression Expression, group_by []Expression, tables map[string]bool) {
+	walk_expression(expression, func(e Expression) bool {
+		for _, group := range group_by {
+			if same_expression(e, group, tables) {
+				return false
+			}
+		}
+
+		if is_aggregate(e) {
+			return false
+		}
+
+		switch column := e.(type) {
+		case *ColumnExpression:
+			panic(fmt.Sprintf("Column %s must appear in the GROUP BY clause or be used in an aggregate function", lex.QuoteIdentifier(column.name.Value())))
+		case *StarExpression:
+			name := "*"
+			if column.table.IsTokenType(lex.TOKEN_IDENTIFIER) {
+				name = lex.QuoteIdentifier(column.table.Value()) + ".*"
+			}
+
+			panic(fmt.Sprintf("Column %s must appear in the GROUP BY clause or be used in an aggregate function", name))
+		}
+
+		return true
+	})
+}
+
+func (content *SelectStatement) expressions() []Expression {
+	expressions := append([]Expression{content.where, content.limit, content.offset}, content.group_by...)
+	return append(expressions, content.output_expressions()...)
+}
+
+func (content *SelectStatement) output_expressions() []Expression {
+	var expressions []Expression
+	for _, column := range content.columns {
+		expressions = append(expressions, column.expression)
+	}
+	expressions = append(expressions, content.having)
+
+	for _, term := range content.order_by {
+		if !content.is_output_name(term.expression) {
+			expressions = append(expressions, term.expression)
+		}
+	}
+
+	return expressions
+}
+
+func (content *SelectStatement) is_output_name(expression Expression) bool {
+	column, ok := expression.(*ColumnExpression)
+	if !ok || column.table.IsTokenType(lex.TOKEN_IDENTIFIER) {
+		return false
+	}
+
+	for _, output := range content.columns {
+		if output.alias.IsTokenType(lex.TOKEN_IDENTIFIER) && output.alias.Value() == column.name.Value() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func check_no_tables(query Query) {
+	switch content := query.(type) {
+	case *SelectStatement:
+		if content.from == nil {
+			check_no_columns(content)
+		}
+	case *CompoundSelect:
+		check_no_tables(content.left)
+		check_no_tables(content.right)
+	}
+}
+
+func check_no_columns(content *SelectStatement) {
+	expressions := append([]Expression{content.where, content.having, content.limit, content.offset}, content.group_by...)
+	for _, column := range content.columns {
+		expressions = append(expressions, column.expression)
+	}
+	for _, term := range content.order_by {
+		if !content.is_output_name(term.expression) {
+			expressions = append(expressions, term.expression)
+		}
+	}
+
+	for _, expression := range expressions {
+		walk_expression(expression, func(e Expression) bool {
+			switch column := e.(type) {
+			case *ColumnExpression:
+				if column.table.IsTokenType(lex.TOKEN_IDENTIFIER) {
+					panic(fmt.Sprintf("Missing FROM-clause entry for table %s", lex.QuoteIdentifier(column.table.Value())))
+				}
+
+				panic(fmt.Sprintf("Column %s does not exist", lex.QuoteIdentifier(column.name.Value())))
+			case *StarExpression:
+				panic("SELECT * with no tables specified is not valid")
+			}
+
+			return true
+		})
+	}
+}
+
+func check_table_names(from TableReference, names map[string]bool) {
+	switch table := from.(type) {
+	case *TableName:
+		name := table.name
+		if table.alias.IsTokenType(lex.TOKEN_IDENTIFIER) {
+			name = table.alias
+		}
+
+		check_table_name(name, names)
+	case *SubqueryTable:
+		check_table_name(table.alias, names)
+	case *JoinClause:
+		check_table_names(table.left, names)
+		check_table_names(table.right, names)
+	}
+}
+
+func check_table_name(name lex.Token, names map[string]bool) {
+	if names[name.Value()] {
+		panic(fmt.Sprintf("Table name %s specified more than once", lex.QuoteIdentifier(name.Value())))
+	}
+
+	names[name.Value()] = true
+}
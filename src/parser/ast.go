@@ -1,22 +1,72 @@
 package parser
 
-import lex "github.com/JamesErrington/tasiadb/src/lexer"
+import (
+	"github.com/JamesErrington/tasiadb/src/functions"
+	lex "github.com/JamesErrington/tasiadb/src/lexer"
+	"github.com/JamesErrington/tasiadb/src/types"
+)
 
 type NodeType int8
 
 const (
-	NODE_NUMBER_VALUE NodeType = iota
+	NODE_INTEGER_VALUE NodeType = iota
+	NODE_REAL_VALUE
 	NODE_TEXT_VALUE
 	NODE_BOOLEAN_VALUE
+	NODE_BLOB_VALUE
+	NODE_DATE_VALUE
+	NODE_TIME_VALUE
+	NODE_TIMESTAMP_VALUE
+	NODE_INTERVAL_VALUE
+	NODE_NULL_VALUE
+	NODE_PARAMETER_EXPRESSION
+	NODE_COLUMN_EXPRESSION
+	NODE_STAR_EXPRESSION
+	NODE_FUNCTION_EXPRESSION
+	NODE_WINDOW_EXPRESSION
+	NODE_SUBQUERY_EXPRESSION
+	NODE_EXISTS_EXPRESSION
+	NODE_IN_EXPRESSION
+	NODE_PATTERN_EXPRESSION
+	NODE_BETWEEN_EXPRESSION
+	NODE_UNARY_EXPRESSION
+	NODE_BINARY_EXPRESSION
+	NODE_IS_NULL_EXPRESSION
+	NODE_CASE_EXPRESSION
+	NODE_CAST_EXPRESSION
+	NODE_TABLE_NAME
+	NODE_JOIN_CLAUSE
+	NODE_SUBQUERY_TABLE
 	NODE_CREATE_TABLE_STATEMENT
 	NODE_INSERT_STATEMENT
 	NODE_SELECT_STATEMENT
+	NODE_COMPOUND_SELECT
+	NODE_WITH_QUERY
+	NODE_UPDATE_STATEMENT
+	NODE_DELETE_STATEMENT
+	NODE_CREATE_INDEX_STATEMENT
+	NODE_DROP_INDEX_STATEMENT
 )
 
 type Node interface {
 	Pos() int
 }
 
+type Expression interface {
+	Node
+	expression_node()
+}
+
+type Query interface {
+	Node
+	query_node()
+}
+
+type TableReference interface {
+	Node
+	table_reference_node()
+}
+
 type Statement struct {
 	Content Node
 }
@@ -26,36 +76,575 @@ func (s *Statement) Pos() int {
 }
 
 type CreateTableStatement struct {
+	_type              NodeType
+	start              int
+	table_name         lex.Token
+	column_names       []lex.Token
+	column_types       []DataType
+	column_constraints [][]Constraint
+	constraints        []Constraint
+}
+
+func (s *CreateTableStatement) Pos() int {
+	return s.start
+}
+
+type CreateIndexStatement struct {
+	_type      NodeType
+	start      int
+	unique     bool
+	index_name lex.Token
+	table_name lex.Token
+	columns    []IndexedColumn
+}
+
+func (s *CreateIndexStatement) Pos() int {
+	return s.start
+}
+
+type IndexedColumn struct {
+	name       lex.Token
+	descending bool
+}
+
+type DropIndexStatement struct {
+	_type      NodeType
+	start      int
+	index_name lex.Token
+}
+
+func (s *DropIndexStatement) Pos() int {
+	return s.start
+}
+
+type DataType struct {
+	name       lex.Token
+	parameters []lex.Token
+}
+
+var data_types = map[lex.TokenType]types.Type{
+	lex.TOKEN_KEYWORD_BLOB:      types.TYPE_BLOB,
+	lex.TOKEN_KEYWORD_BOOLEAN:   types.TYPE_BOOLEAN,
+	lex.TOKEN_KEYWORD_DATE:      types.TYPE_DATE,
+	lex.TOKEN_KEYWORD_DECIMAL:   types.TYPE_DECIMAL,
+	lex.TOKEN_KEYWORD_INTEGER:   types.TYPE_INTEGER,
+	lex.TOKEN_KEYWORD_INTERVAL:  types.TYPE_INTERVAL,
+	lex.TOKEN_KEYWORD_NUMBER:    types.TYPE_DECIMAL,
+	lex.TOKEN_KEYWORD_REAL:      types.TYPE_REAL,
+	lex.TOKEN_KEYWORD_TEXT:      types.TYPE_TEXT,
+	lex.TOKEN_KEYWORD_TIME:      types.TYPE_TIME,
+	lex.TOKEN_KEYWORD_TIMESTAMP: types.TYPE_TIMESTAMP,
+}
+
+func (data_type DataType) Type() types.Type {
+	return data_types[data_type.name.Type()]
+}
+
+func (data_type DataType) Cast(value types.Value) (types.Value, error) {
+	if len(data_type.parameters) == 0 {
+		return types.Cast(value, data_type.Type())
+	}
+
+	precision, err := types.ParseInteger(data_type.parameters[0].Value())
+	if err != nil {
+		return types.Value{}, err
+	}
+
+	scale := types.NewInteger(0)
+	if len(data_type.parameters) > 1 {
+		if scale, err = types.ParseInteger(data_type.parameters[1].Value()); err != nil {
+			return types.Value{}, err
+		}
+	}
+
+	return types.CastDecimal(value, int(precision.Integer()), int(scale.Integer()))
+}
+
+type ConstraintType int8
+
+const (
+	CONSTRAINT_PRIMARY_KEY ConstraintType = iota
+	CONSTRAINT_NOT_NULL
+	CONSTRAINT_UNIQUE
+	CONSTRAINT_DEFAULT
+	CONSTRAINT_CHECK
+	CONSTRAINT_FOREIGN_KEY
+	CONSTRAINT_AUTOINCREMENT
+	CONSTRAINT_IDENTITY_ALWAYS
+	CONSTRAINT_IDENTITY_BY_DEFAULT
+)
+
+type Constraint struct {
+	_type      ConstraintType
+	start      int
+	name       lex.Token
+	columns    []lex.Token
+	expression Expression
+	reference  *Reference
+}
+
+type ReferentialAction int8
+
+const (
+	ACTION_NO_ACTION ReferentialAction = iota
+	ACTION_RESTRICT
+	ACTION_CASCADE
+	ACTION_SET_NULL
+	ACTION_SET_DEFAULT
+)
+
+type Reference struct {
+	table_name         lex.Token
+	columns            []lex.Token
+	on_delete          ReferentialAction
+	on_update          ReferentialAction
+	deferrable         bool
+	initially_deferred bool
+}
+
+type InsertStatement struct {
 	_type        NodeType
 	start        int
 	table_name   lex.Token
 	column_names []lex.Token
-	column_types []lex.Token
+	rows         [][]Expression
+	query        Query
+	on_conflict  *OnConflict
+	returning    []ResultColumn
 }
 
-func (s *CreateTableStatement) Pos() int {
+func (s *InsertStatement) Pos() int {
 	return s.start
 }
 
-type InsertStatement struct {
+type OnConflict struct {
+	columns       []lex.Token
+	do_update     bool
+	column_names  []lex.Token
+	column_values []Expression
+	where         Expression
+}
+
+type UpdateStatement struct {
 	_type         NodeType
 	start         int
 	table_name    lex.Token
 	column_names  []lex.Token
-	column_values []lex.Token
+	column_values []Expression
+	where         Expression
+	returning     []ResultColumn
 }
 
-func (s *InsertStatement) Pos() int {
+func (s *UpdateStatement) Pos() int {
 	return s.start
 }
 
-type SelectStatement struct {
+type DeleteStatement struct {
 	_type      NodeType
 	start      int
-	columns    []lex.Token
 	table_name lex.Token
+	where      Expression
+	returning  []ResultColumn
+}
+
+func (s *DeleteStatement) Pos() int {
+	return s.start
+}
+
+type SelectStatement struct {
+	_type    NodeType
+	start    int
+	distinct bool
+	columns  []ResultColumn
+	from     TableReference
+	where    Expression
+	group_by []Expression
+	having   Expression
+	order_by []OrderingTerm
+	limit    Expression
+	offset   Expression
 }
 
 func (s *SelectStatement) Pos() int {
 	return s.start
 }
+
+func (s *SelectStatement) query_node() {}
+
+type SetOperator int8
+
+const (
+	SET_UNION SetOperator = iota
+	SET_INTERSECT
+	SET_EXCEPT
+)
+
+var set_operator_names = [...]string{
+	SET_UNION:     "UNION",
+	SET_INTERSECT: "INTERSECT",
+	SET_EXCEPT:    "EXCEPT",
+}
+
+func (operator SetOperator) String() string {
+	return set_operator_names[operator]
+}
+
+type CompoundSelect struct {
+	_type    NodeType
+	start    int
+	operator SetOperator
+	all      bool
+	left     Query
+	right    Query
+	order_by []OrderingTerm
+	limit    Expression
+	offset   Expression
+}
+
+func (s *CompoundSelect) Pos() int {
+	return s.start
+}
+
+func (s *CompoundSelect) query_node() {}
+
+type CommonTable struct {
+	name    lex.Token
+	columns []lex.Token
+	query   Query
+}
+
+type WithQuery struct {
+	_type     NodeType
+	start     int
+	recursive bool
+	tables    []CommonTable
+	query     Query
+}
+
+func (s *WithQuery) Pos() int {
+	return s.start
+}
+
+func (s *WithQuery) query_node() {}
+
+type JoinType int8
+
+const (
+	JOIN_INNER JoinType = iota
+	JOIN_LEFT
+	JOIN_RIGHT
+	JOIN_FULL
+	JOIN_CROSS
+)
+
+type TableName struct {
+	_type NodeType
+	start int
+	name  lex.Token
+	alias lex.Token
+}
+
+func (t *TableName) Pos() int {
+	return t.start
+}
+
+func (t *TableName) table_reference_node() {}
+
+type SubqueryTable struct {
+	_type NodeType
+	start int
+	query Query
+	alias lex.Token
+}
+
+func (t *SubqueryTable) Pos() int {
+	return t.start
+}
+
+func (t *SubqueryTable) table_reference_node() {}
+
+type JoinClause struct {
+	_type     NodeType
+	start     int
+	join_type JoinType
+	left      TableReference
+	right     TableReference
+	on        Expression
+	using     []lex.Token
+}
+
+func (t *JoinClause) Pos() int {
+	return t.start
+}
+
+func (t *JoinClause) table_reference_node() {}
+
+type ResultColumn struct {
+	expression Expression
+	alias      lex.Token
+}
+
+func (column ResultColumn) Name() string {
+	if column.alias.IsTokenType(lex.TOKEN_IDENTIFIER) {
+		return column.alias.Value()
+	}
+
+	switch expression := column.expression.(type) {
+	case *ColumnExpression:
+		return expression.name.Value()
+	case *FunctionExpression:
+		return expression.name.Value()
+	case *WindowExpression:
+		return expression.function.name.Value()
+	case *ExistsExpression:
+		return "exists"
+	case *CaseExpression:
+		return "case"
+	case *CastExpression:
+		return ResultColumn{expression: expression.operand}.Name()
+	}
+
+	return "?column?"
+}
+
+type OrderingTerm struct {
+	expression  Expression
+	descending  bool
+	nulls_first bool
+}
+
+type LiteralExpression struct {
+	_type NodeType
+	start int
+	value lex.Token
+}
+
+func (e *LiteralExpression) Pos() int {
+	return e.start
+}
+
+func (e *LiteralExpression) expression_node() {}
+
+type UnaryExpression struct {
+	_type    NodeType
+	start    int
+	operator lex.Token
+	operand  Expression
+}
+
+func (e *UnaryExpression) Pos() int {
+	return e.start
+}
+
+func (e *UnaryExpression) expression_node() {}
+
+type BinaryExpression struct {
+	_type    NodeType
+	start    int
+	operator lex.Token
+	left     Expression
+	right    Expression
+}
+
+func (e *BinaryExpression) Pos() int {
+	return e.start
+}
+
+func (e *BinaryExpression) expression_node() {}
+
+type IsNullExpression struct {
+	_type   NodeType
+	start   int
+	operand Expression
+	negated bool
+}
+
+func (e *IsNullExpression) Pos() int {
+	return e.start
+}
+
+func (e *IsNullExpression) expression_node() {}
+
+type WhenClause struct {
+	condition Expression
+	result    Expression
+}
+
+type CaseExpression struct {
+	_type       NodeType
+	start       int
+	operand     Expression
+	whens       []WhenClause
+	else_result Expression
+}
+
+func (e *CaseExpression) Pos() int {
+	return e.start
+}
+
+func (e *CaseExpression) expression_node() {}
+
+type CastExpression struct {
+	_type     NodeType
+	start     int
+	operand   Expression
+	data_type DataType
+}
+
+func (e *CastExpression) Pos() int {
+	return e.start
+}
+
+func (e *CastExpression) expression_node() {}
+
+type ColumnExpression struct {
+	_type NodeType
+	start int
+	table lex.Token
+	name  lex.Token
+}
+
+func (e *ColumnExpression) Pos() int {
+	return e.start
+}
+
+func (e *ColumnExpression) expression_node() {}
+
+type ParameterExpression struct {
+	_type NodeType
+	start int
+	index int
+}
+
+func (e *ParameterExpression) Pos() int {
+	return e.start
+}
+
+func (e *ParameterExpression) expression_node() {}
+
+type StarExpression struct {
+	_type NodeType
+	start int
+	table lex.Token
+}
+
+func (e *StarExpression) Pos() int {
+	return e.start
+}
+
+func (e *StarExpression) expression_node() {}
+
+type FunctionExpression struct {
+	_type     NodeType
+	start     int
+	name      lex.Token
+	arguments []Expression
+	distinct  bool
+	star      bool
+}
+
+func (e *FunctionExpression) Pos() int {
+	return e.start
+}
+
+func (e *FunctionExpression) expression_node() {}
+
+type FrameBound struct {
+	kind   functions.BoundKind
+	offset Expression
+}
+
+type WindowFrame struct {
+	mode  functions.FrameMode
+	start FrameBound
+	end   FrameBound
+}
+
+type WindowDefinition struct {
+	partition_by []Expression
+	order_by     []OrderingTerm
+	frame        WindowFrame
+}
+
+type WindowExpression struct {
+	_type    NodeType
+	start    int
+	function *FunctionExpression
+	window   WindowDefinition
+}
+
+func (e *WindowExpression) Pos() int {
+	return e.start
+}
+
+func (e *WindowExpression) expression_node() {}
+
+type SubqueryExpression struct {
+	_type NodeType
+	start int
+	query Query
+}
+
+func (e *SubqueryExpression) Pos() int {
+	return e.start
+}
+
+func (e *SubqueryExpression) expression_node() {}
+
+type ExistsExpression struct {
+	_type NodeType
+	start int
+	query Query
+}
+
+func (e *ExistsExpression) Pos() int {
+	return e.start
+}
+
+func (e *ExistsExpression) expression_node() {}
+
+type InExpression struct {
+	_type   NodeType
+	start   int
+	operand Expression
+	query   Query
+	values  []Expression
+	negated bool
+}
+
+func (e *InExpression) Pos() int {
+	return e.start
+}
+
+func (e *InExpression) expression_node() {}
+
+type PatternExpression struct {
+	_type    NodeType
+	start    int
+	operator lex.Token
+	operand  Expression
+	pattern  Expression
+	escape   Expression
+	negated  bool
+}
+
+func (e *PatternExpression) Pos() int {
+	return e.start
+}
+
+func (e *PatternExpression) expression_node() {}
+
+type BetweenExpression struct {
+	_type   NodeType
+	start   int
+	operand Expression
+	low     Expression
+	high    Expression
+	negated bool
+}
+
+func (e *BetweenExpression) Pos() int {
+	return e.start
+}
+
+func (e *BetweenExpression) expression_node() {}
@@ -2,10 +2,15 @@ package parser
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/JamesErrington/tasiadb/src/functions"
 	lex "github.com/JamesErrington/tasiadb/src/lexer"
+	"github.com/JamesErrington/tasiadb/src/types"
 )
 
+var niladic_functions = map[string]bool{"current_date": true, "current_time": true, "current_timestamp": true}
+
 type Parser struct {
 	source        string
 	source_length int
@@ -13,11 +18,14 @@ type Parser struct {
 	start         int
 	current       lex.Token
 	previous      lex.Token
+	parameters    int
+	numbered      bool
+	outer_queries int
 }
 
 func NewParser(source string) *Parser {
 	lexer := lex.NewLexer(source)
-	return &Parser{source, len(source), lexer, 0, lex.Token{}, lex.Token{}}
+	return &Parser{source, len(source), lexer, 0, lex.Token{}, lex.Token{}, 0, false, 0}
 }
 
 func (parser *Parser) handle_error() {
@@ -40,6 +48,8 @@ func (parser *Parser) Parse() []Statement {
 
 		parser.consume_token(lex.TOKEN_SEMI_COLON, "Expected semi colon at end of statement")
 		parser.start = parser.lexer.StartIndex()
+		parser.parameters = 0
+		parser.numbered = false
 	}
 
 	return statements
@@ -60,6 +70,13 @@ func (parser *Parser) advance() {
 	}
 }
 
+func (parser *Parser) peek_token() lex.Token {
+	lexer := *parser.lexer
+	token, _ := lexer.NextToken()
+
+	return token
+}
+
 func (parser *Parser) match_token(token_type lex.TokenType) bool {
 	if parser.current.IsTokenType(token_type) {
 		parser.advance()
@@ -69,6 +86,20 @@ func (parser *Parser) match_token(token_type lex.TokenType) bool {
 	return false
 }
 
+func (parser *Parser) match_identifier() bool {
+	if parser.current.IsUnreserved() {
+		parser.current = parser.current.AsIdentifier()
+	}
+
+	return parser.match_token(lex.TOKEN_IDENTIFIER)
+}
+
+func (parser *Parser) consume_identifier(message string) {
+	if !parser.match_identifier() {
+		panic(message)
+	}
+}
+
 func (parser *Parser) consume_token(token_type lex.TokenType, message string) {
 	if parser.current.IsTokenType(token_type) {
 		parser.advance()
@@ -93,6 +124,22 @@ func (parser *Parser) parse_statement() Statement {
 		return parser.parse_select_statement()
 	}
 
+	if parser.match_token(lex.TOKEN_KEYWORD_WITH) {
+		return Statement{parser.parse_with(parser.start)}
+	}
+
+	if parser.match_token(lex.TOKEN_KEYWORD_UPDATE) {
+		return parser.parse_update_statement()
+	}
+
+	if parser.match_token(lex.TOKEN_KEYWORD_DELETE) {
+		return parser.parse_delete_statement()
+	}
+
+	if parser.match_token(lex.TOKEN_KEYWORD_DROP) {
+		return parser.parse_drop_statement()
+	}
+
 	return statement
 }
 
@@ -102,36 +149,48 @@ func (parser *Parser) parse_create_statement() Statement {
 		return Statement{&content}
 	}
 
+	if parser.match_token(lex.TOKEN_KEYWORD_UNIQUE) {
+		parser.consume_token(lex.TOKEN_KEYWORD_INDEX, "Expected INDEX")
+		content := parser.parse_create_index_statement(true)
+		return Statement{&content}
+	}
+
+	if parser.match_token(lex.TOKEN_KEYWORD_INDEX) {
+		content := parser.parse_create_index_statement(false)
+		return Statement{&content}
+	}
+
 	panic("Unhandled CREATE statement")
 }
 
-func (parser *Parser) parse_create_table_statement() CreateTableStatement {
-	parser.consume_token(lex.TOKEN_IDENTIFIER, "Expected identifier")
-	table_name_token := parser.previous
-
-	column_names, column_types := parser.parse_column_definitions()
+func (parser *Parser) parse_create_index_statement(unique bool) CreateIndexStatement {
+	content := CreateIndexStatement{_type: NODE_CREATE_INDEX_STATEMENT, start: parser.start, unique: unique}
 
-	return CreateTableStatement{NODE_CREATE_TABLE_STATEMENT, parser.start, table_name_token, column_names, column_types}
-}
+	parser.consume_identifier("Expected identifier")
+	content.index_name = parser.previous
 
-func (parser *Parser) parse_column_definitions() ([]lex.Token, []lex.Token) {
-	var column_names []lex.Token
-	var column_types []lex.Token
+	parser.consume_token(lex.TOKEN_KEYWORD_ON, "Expected ON")
+	parser.consume_identifier("Expected identifier")
+	content.table_name = parser.previous
 
+	columns := make(map[string]bool)
 	parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
 	for {
-		if !parser.match_token(lex.TOKEN_IDENTIFIER) {
-			panic("Expected identifier")
+		parser.consume_identifier("Expected identifier")
+		column := IndexedColumn{name: parser.previous}
+
+		if columns[column.name.Value()] {
+			panic(fmt.Sprintf("Column %s specified more than once", lex.QuoteIdentifier(column.name.Value())))
 		}
-		column_names = append(column_names, parser.previous)
+		columns[column.name.Value()] = true
 
-		parser.advance()
-		column_type_token := parser.previous
-		if !column_type_token.IsDataType() {
-			panic("Expected Type")
+		if parser.match_token(lex.TOKEN_KEYWORD_DESC) {
+			column.descending = true
+		} else {
+			parser.match_token(lex.TOKEN_KEYWORD_ASC)
 		}
 
-		column_types = append(column_types, parser.previous)
+		content.columns = append(content.columns, column)
 
 		if parser.match_token(lex.TOKEN_COMMA) {
 			continue
@@ -144,48 +203,286 @@ func (parser *Parser) parse_column_definitions() ([]lex.Token, []lex.Token) {
 		panic("Expected ',' or ')")
 	}
 
-	return column_names, column_types
+	return content
 }
 
-func (parser *Parser) parse_insert_statement() Statement {
-	parser.consume_token(lex.TOKEN_KEYWORD_INTO, "Expected INTO")
+func (parser *Parser) parse_drop_statement() Statement {
+	if parser.match_token(lex.TOKEN_KEYWORD_INDEX) {
+		parser.consume_identifier("Expected identifier")
+		return Statement{&DropIndexStatement{NODE_DROP_INDEX_STATEMENT, parser.start, parser.previous}}
+	}
 
-	parser.consume_token(lex.TOKEN_IDENTIFIER, "Expected identifier")
+	panic("Unhandled DROP statement")
+}
+
+func (parser *Parser) parse_create_table_statement() CreateTableStatement {
+	parser.consume_identifier("Expected identifier")
 	table_name_token := parser.previous
 
-	var column_names []lex.Token
+	content := CreateTableStatement{_type: NODE_CREATE_TABLE_STATEMENT, start: parser.start, table_name: table_name_token}
+	parser.parse_table_elements(&content)
+	parser.check_table_constraints(&content)
 
-	if parser.match_token(lex.TOKEN_LEFT_PAREN) {
-		for {
-			parser.consume_token(lex.TOKEN_IDENTIFIER, "Expected identifier")
-			column_names = append(column_names, parser.previous)
+	return content
+}
 
-			if parser.match_token(lex.TOKEN_COMMA) {
-				continue
+func (parser *Parser) parse_table_elements(content *CreateTableStatement) {
+	parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+	for {
+		if parser.is_table_constraint() {
+			content.constraints = append(content.constraints, parser.parse_table_constraint())
+		} else {
+			parser.consume_identifier("Expected identifier")
+			column_name := parser.previous
+			column_type := parser.parse_data_type()
+			column_constraints := parser.parse_column_constraints()
+			parser.check_column_constraints(column_name, column_type, column_constraints)
+
+			content.column_names = append(content.column_names, column_name)
+			content.column_types = append(content.column_types, column_type)
+			content.column_constraints = append(content.column_constraints, column_constraints)
+		}
+
+		if parser.match_token(lex.TOKEN_COMMA) {
+			continue
+		}
+
+		if parser.match_token(lex.TOKEN_RIGHT_PAREN) {
+			break
+		}
+
+		panic("Expected ',' or ')")
+	}
+}
+
+func (parser *Parser) is_table_constraint() bool {
+	switch {
+	case parser.current.IsTokenType(lex.TOKEN_KEYWORD_CONSTRAINT),
+		parser.current.IsTokenType(lex.TOKEN_KEYWORD_PRIMARY),
+		parser.current.IsTokenType(lex.TOKEN_KEYWORD_UNIQUE),
+		parser.current.IsTokenType(lex.TOKEN_KEYWORD_CHECK),
+		parser.current.IsTokenType(lex.TOKEN_KEYWORD_FOREIGN):
+		return true
+	default:
+		return false
+	}
+}
+
+func (parser *Parser) parse_constraint_name() (int, lex.Token) {
+	start := parser.current.Offset()
+
+	if parser.match_token(lex.TOKEN_KEYWORD_CONSTRAINT) {
+		parser.consume_identifier("Expected constraint name")
+		return start, parser.previous
+	}
+
+	return start, lex.Token{}
+}
+
+func (parser *Parser) parse_column_constraints() []Constraint {
+	var constraints []Constraint
+
+	for {
+		start, name := parser.parse_constraint_name()
+		named := name.IsTokenType(lex.TOKEN_IDENTIFIER)
+
+		switch {
+		case parser.match_token(lex.TOKEN_KEYWORD_PRIMARY):
+			parser.consume_token(lex.TOKEN_KEYWORD_KEY, "Expected KEY")
+			constraints = append(constraints, Constraint{CONSTRAINT_PRIMARY_KEY, start, name, nil, nil, nil})
+		case parser.match_token(lex.TOKEN_KEYWORD_AUTOINCREMENT):
+			constraints = append(constraints, Constraint{CONSTRAINT_AUTOINCREMENT, start, name, nil, nil, nil})
+		case parser.match_token(lex.TOKEN_KEYWORD_GENERATED):
+			constraints = append(constraints, Constraint{parser.parse_identity(), start, name, nil, nil, nil})
+		case parser.match_token(lex.TOKEN_KEYWORD_NOT):
+			parser.consume_token(lex.TOKEN_KEYWORD_NULL, "Expected NULL")
+			constraints = append(constraints, Constraint{CONSTRAINT_NOT_NULL, start, name, nil, nil, nil})
+		case parser.match_token(lex.TOKEN_KEYWORD_NULL):
+			continue
+		case parser.match_token(lex.TOKEN_KEYWORD_UNIQUE):
+			constraints = append(constraints, Constraint{CONSTRAINT_UNIQUE, start, name, nil, nil, nil})
+		case parser.match_token(lex.TOKEN_KEYWORD_DEFAULT):
+			expression := parser.parse_unary()
+			check_plain_expression(expression, "DEFAULT expressions")
+			constraints = append(constraints, Constraint{CONSTRAINT_DEFAULT, start, name, nil, expression, nil})
+		case parser.match_token(lex.TOKEN_KEYWORD_CHECK):
+			constraints = append(constraints, Constraint{CONSTRAINT_CHECK, start, name, nil, parser.parse_check_expression(), nil})
+		case parser.match_token(lex.TOKEN_KEYWORD_REFERENCES):
+			reference := parser.parse_reference()
+			if len(reference.columns) > 1 {
+				panic("Number of referencing and referenced columns for foreign key disagree")
 			}
 
-			if parser.match_token(lex.TOKEN_RIGHT_PAREN) {
-				break
+			constraints = append(constraints, Constraint{CONSTRAINT_FOREIGN_KEY, start, name, nil, nil, reference})
+		case named:
+			panic("Expected constraint")
+		default:
+			return constraints
+		}
+	}
+}
+
+func (parser *Parser) parse_identity() ConstraintType {
+	constraint_type := CONSTRAINT_IDENTITY_ALWAYS
+
+	if parser.match_token(lex.TOKEN_KEYWORD_BY) {
+		parser.consume_token(lex.TOKEN_KEYWORD_DEFAULT, "Expected DEFAULT")
+		constraint_type = CONSTRAINT_IDENTITY_BY_DEFAULT
+	} else {
+		parser.consume_token(lex.TOKEN_KEYWORD_ALWAYS, "Expected ALWAYS or BY DEFAULT")
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_AS, "Expected AS")
+	parser.consume_token(lex.TOKEN_KEYWORD_IDENTITY, "Expected IDENTITY")
+
+	return constraint_type
+}
+
+func (parser *Parser) check_column_constraints(column_name lex.Token, data_type DataType, constraints []Constraint) {
+	name := lex.QuoteIdentifier(column_name.Value())
+	is_integer := data_type.name.IsTokenType(lex.TOKEN_KEYWORD_INTEGER)
+	primary_key, autoincrement, has_default, identities := false, false, false, 0
+
+	for _, constraint := range constraints {
+		switch constraint._type {
+		case CONSTRAINT_PRIMARY_KEY:
+			primary_key = true
+		case CONSTRAINT_DEFAULT:
+			has_default = true
+		case CONSTRAINT_AUTOINCREMENT:
+			autoincrement = true
+			identities += 1
+		case CONSTRAINT_IDENTITY_ALWAYS, CONSTRAINT_IDENTITY_BY_DEFAULT:
+			if !is_integer {
+				panic(fmt.Sprintf("Identity column %s must be of type INTEGER", name))
 			}
+			identities += 1
+		}
+	}
 
-			panic("Expected ',' or ')")
+	if autoincrement && (!primary_key || !is_integer) {
+		panic("AUTOINCREMENT is only allowed on an INTEGER PRIMARY KEY")
+	}
+
+	if identities > 1 {
+		panic(fmt.Sprintf("Multiple identity specifications for column %s", name))
+	}
+
+	if identities > 0 && has_default {
+		panic(fmt.Sprintf("Both default and identity specified for column %s", name))
+	}
+}
+
+func (parser *Parser) parse_table_constraint() Constraint {
+	start, name := parser.parse_constraint_name()
+
+	switch {
+	case parser.match_token(lex.TOKEN_KEYWORD_PRIMARY):
+		parser.consume_token(lex.TOKEN_KEYWORD_KEY, "Expected KEY")
+		return Constraint{CONSTRAINT_PRIMARY_KEY, start, name, parser.parse_column_list(), nil, nil}
+	case parser.match_token(lex.TOKEN_KEYWORD_UNIQUE):
+		return Constraint{CONSTRAINT_UNIQUE, start, name, parser.parse_column_list(), nil, nil}
+	case parser.match_token(lex.TOKEN_KEYWORD_CHECK):
+		return Constraint{CONSTRAINT_CHECK, start, name, nil, parser.parse_check_expression(), nil}
+	case parser.match_token(lex.TOKEN_KEYWORD_FOREIGN):
+		parser.consume_token(lex.TOKEN_KEYWORD_KEY, "Expected KEY")
+		columns := parser.parse_column_list()
+
+		parser.consume_token(lex.TOKEN_KEYWORD_REFERENCES, "Expected REFERENCES")
+		reference := parser.parse_reference()
+		if reference.columns != nil && len(reference.columns) != len(columns) {
+			panic("Number of referencing and referenced columns for foreign key disagree")
 		}
+
+		return Constraint{CONSTRAINT_FOREIGN_KEY, start, name, columns, nil, reference}
 	}
 
-	parser.consume_token(lex.TOKEN_KEYWORD_VALUES, "Expected VALUES")
+	panic("Expected PRIMARY KEY, UNIQUE, CHECK or FOREIGN KEY")
+}
 
-	var column_values []lex.Token
+func (parser *Parser) parse_reference() *Reference {
+	parser.consume_identifier("Expected identifier")
+	reference := Reference{table_name: parser.previous}
 
-	parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+	if parser.current.IsTokenType(lex.TOKEN_LEFT_PAREN) {
+		reference.columns = parser.parse_column_list()
+	}
+
+	not_deferrable := false
 	for {
+		switch {
+		case parser.match_token(lex.TOKEN_KEYWORD_ON):
+			if parser.match_token(lex.TOKEN_KEYWORD_DELETE) {
+				reference.on_delete = parser.parse_referential_action()
+			} else if parser.match_token(lex.TOKEN_KEYWORD_UPDATE) {
+				reference.on_update = parser.parse_referential_action()
+			} else {
+				panic("Expected DELETE or UPDATE")
+			}
+		case parser.match_token(lex.TOKEN_KEYWORD_DEFERRABLE):
+			reference.deferrable = true
+		case parser.current.IsTokenType(lex.TOKEN_KEYWORD_NOT) && parser.peek_token().IsTokenType(lex.TOKEN_KEYWORD_DEFERRABLE):
+			parser.advance()
+			parser.advance()
+			not_deferrable = true
+		case parser.match_token(lex.TOKEN_KEYWORD_INITIALLY):
+			if parser.match_token(lex.TOKEN_KEYWORD_DEFERRED) {
+				reference.initially_deferred = true
+			} else if parser.match_token(lex.TOKEN_KEYWORD_IMMEDIATE) {
+				reference.initially_deferred = false
+			} else {
+				panic("Expected DEFERRED or IMMEDIATE")
+			}
+		default:
+			if reference.initially_deferred {
+				if not_deferrable {
+					panic("Constraint declared INITIALLY DEFERRED must be DEFERRABLE")
+				}
+				reference.deferrable = true
+			}
 
-		parser.advance()
-		column_value_token := parser.previous
-		if !column_value_token.IsValueType() {
-			panic("Expected Type")
+			return &reference
+		}
+	}
+}
+
+func (parser *Parser) parse_referential_action() ReferentialAction {
+	switch {
+	case parser.match_token(lex.TOKEN_KEYWORD_CASCADE):
+		return ACTION_CASCADE
+	case parser.match_token(lex.TOKEN_KEYWORD_RESTRICT):
+		return ACTION_RESTRICT
+	case parser.match_token(lex.TOKEN_KEYWORD_NO):
+		parser.consume_token(lex.TOKEN_KEYWORD_ACTION, "Expected ACTION")
+		return ACTION_NO_ACTION
+	case parser.match_token(lex.TOKEN_KEYWORD_SET):
+		if parser.match_token(lex.TOKEN_KEYWORD_NULL) {
+			return ACTION_SET_NULL
 		}
 
-		column_values = append(column_values, column_value_token)
+		parser.consume_token(lex.TOKEN_KEYWORD_DEFAULT, "Expected NULL or DEFAULT")
+		return ACTION_SET_DEFAULT
+	}
+
+	panic("Expected CASCADE, RESTRICT, NO ACTION, SET NULL or SET DEFAULT")
+}
+
+func (parser *Parser) parse_check_expression() Expression {
+	parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+	expression := parser.parse_expression()
+	parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ')'")
+	check_plain_expression(expression, "check constraints")
+
+	return expression
+}
+
+func (parser *Parser) parse_column_list() []lex.Token {
+	var column_names []lex.Token
+
+	parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+	for {
+		parser.consume_identifier("Expected identifier")
+		column_names = append(column_names, parser.previous)
 
 		if parser.match_token(lex.TOKEN_COMMA) {
 			continue
@@ -198,46 +495,1228 @@ func (parser *Parser) parse_insert_statement() Statement {
 		panic("Expected ',' or ')")
 	}
 
-	content := InsertStatement{NODE_INSERT_STATEMENT, parser.start, table_name_token, column_names, column_values}
-	return Statement{&content}
+	return column_names
 }
 
-func (parser *Parser) parse_select_statement() Statement {
-	var columns []lex.Token
-	seen_from := false
+func (parser *Parser) check_table_constraints(content *CreateTableStatement) {
+	columns := make(map[string]bool)
+	for _, name := range content.column_names {
+		if columns[name.Value()] {
+			panic(fmt.Sprintf("Column %s specified more than once", lex.QuoteIdentifier(name.Value())))
+		}
+		columns[name.Value()] = true
+	}
 
-	if parser.match_token(lex.TOKEN_ASTERISK) {
-		columns = append(columns, parser.previous)
-	} else {
-		for {
-			if !parser.match_token(lex.TOKEN_IDENTIFIER) {
-				panic("Expected identifier")
+	primary_keys := 0
+	for _, constraints := range content.column_constraints {
+		for _, constraint := range constraints {
+			if constraint._type == CONSTRAINT_PRIMARY_KEY {
+				primary_keys += 1
+			}
+		}
+	}
+
+	for _, constraint := range content.constraints {
+		if constraint._type == CONSTRAINT_PRIMARY_KEY {
+			primary_keys += 1
+		}
+
+		key := make(map[string]bool)
+		for _, name := range constraint.columns {
+			if !columns[name.Value()] {
+				panic(fmt.Sprintf("Column %s named in key does not exist", lex.QuoteIdentifier(name.Value())))
+			}
+
+			if key[name.Value()] {
+				switch constraint._type {
+				case CONSTRAINT_PRIMARY_KEY:
+					panic(fmt.Sprintf("Column %s appears twice in primary key constraint", lex.QuoteIdentifier(name.Value())))
+				case CONSTRAINT_UNIQUE:
+					panic(fmt.Sprintf("Column %s appears twice in unique constraint", lex.QuoteIdentifier(name.Value())))
+				}
+			}
+			key[name.Value()] = true
+		}
+	}
+
+	for _, constraint := range content.foreign_keys() {
+		if constraint.reference.table_name.Value() != content.table_name.Value() {
+			continue
+		}
+
+		for _, name := range constraint.reference.columns {
+			if !columns[name.Value()] {
+				panic(fmt.Sprintf("Column %s referenced in foreign key constraint does not exist", lex.QuoteIdentifier(name.Value())))
+			}
+		}
+	}
+
+	if primary_keys > 1 {
+		panic(fmt.Sprintf("Multiple primary keys for table %s are not allowed", lex.QuoteIdentifier(content.table_name.Value())))
+	}
+}
+
+func (parser *Parser) parse_data_type() DataType {
+	parser.advance()
+	type_token := parser.previous
+	if !type_token.IsDataType() {
+		panic("Expected Type")
+	}
+
+	numeric := type_token.IsTokenType(lex.TOKEN_KEYWORD_DECIMAL) || type_token.IsTokenType(lex.TOKEN_KEYWORD_NUMBER)
+	if !numeric || !parser.match_token(lex.TOKEN_LEFT_PAREN) {
+		return DataType{type_token, nil}
+	}
+
+	parser.consume_token(lex.TOKEN_LITERAL_INTEGER, "Expected DECIMAL precision")
+	precision_token := parser.previous
+	precision := parser.parse_integer_parameter(precision_token)
+	if precision < 1 || precision > types.MAX_DECIMAL_PRECISION {
+		panic(fmt.Sprintf("DECIMAL precision must be between 1 and %d", types.MAX_DECIMAL_PRECISION))
+	}
+
+	parameters := []lex.Token{precision_token}
+
+	if parser.match_token(lex.TOKEN_COMMA) {
+		parser.consume_token(lex.TOKEN_LITERAL_INTEGER, "Expected DECIMAL scale")
+		scale_token := parser.previous
+		scale := parser.parse_integer_parameter(scale_token)
+		if scale > precision {
+			panic("DECIMAL scale must not exceed its precision")
+		}
+
+		parameters = append(parameters, scale_token)
+	}
+
+	parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ')'")
+
+	return DataType{type_token, parameters}
+}
+
+func (parser *Parser) parse_integer_parameter(token lex.Token) int64 {
+	value, err := types.ParseInteger(token.Value())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return value.Integer()
+}
+
+func (content *CreateTableStatement) foreign_keys() []Constraint {
+	var foreign_keys []Constraint
+
+	for _, constraints := range content.column_constraints {
+		for _, constraint := range constraints {
+			if constraint._type == CONSTRAINT_FOREIGN_KEY {
+				foreign_keys = append(foreign_keys, constraint)
 			}
+		}
+	}
+
+	for _, constraint := range content.constraints {
+		if constraint._type == CONSTRAINT_FOREIGN_KEY {
+			foreign_keys = append(foreign_keys, constraint)
+		}
+	}
+
+	return foreign_keys
+}
+
+func (parser *Parser) parse_insert_statement() Statement {
+	parser.consume_token(lex.TOKEN_KEYWORD_INTO, "Expected INTO")
+
+	parser.consume_identifier("Expected identifier")
+	table_name_token := parser.previous
+
+	var column_names []lex.Token
+
+	if parser.current.IsTokenType(lex.TOKEN_LEFT_PAREN) {
+		column_names = parser.parse_column_list()
+	}
+
+	var rows [][]Expression
+	var query Query
 
-			columns = append(columns, parser.previous)
+	switch {
+	case parser.match_token(lex.TOKEN_KEYWORD_VALUES):
+		rows = parser.parse_values_rows()
+	case parser.match_token(lex.TOKEN_KEYWORD_SELECT):
+		query = parser.parse_query(parser.previous.Offset())
+	case parser.match_token(lex.TOKEN_KEYWORD_WITH):
+		query = parser.parse_with(parser.previous.Offset())
+	case parser.match_token(lex.TOKEN_KEYWORD_DEFAULT):
+		parser.consume_token(lex.TOKEN_KEYWORD_VALUES, "Expected VALUES")
+		if column_names != nil {
+			panic("DEFAULT VALUES cannot be used with a column list")
+		}
+	default:
+		panic("Expected VALUES, SELECT or DEFAULT VALUES")
+	}
+
+	for _, row := range rows {
+		if column_names == nil {
+			break
+		}
+
+		check_insert_width(len(row), len(column_names))
+	}
+
+	if width, ok := query_width(query); ok && column_names != nil {
+		check_insert_width(width, len(column_names))
+	}
+
+	var on_conflict *OnConflict
+	if parser.match_token(lex.TOKEN_KEYWORD_ON) {
+		on_conflict = parser.parse_on_conflict()
+	}
+
+	returning := parser.parse_returning()
+
+	content := InsertStatement{NODE_INSERT_STATEMENT, parser.start, table_name_token, column_names, rows, query, on_conflict, returning}
+	return Statement{&content}
+}
+
+func check_insert_width(expressions int, columns int) {
+	if expressions > columns {
+		panic("INSERT has more expressions than target columns")
+	}
+
+	if expressions < columns {
+		panic("INSERT has more target columns than expressions")
+	}
+}
+
+func (parser *Parser) parse_on_conflict() *OnConflict {
+	parser.consume_token(lex.TOKEN_KEYWORD_CONFLICT, "Expected CONFLICT")
+
+	var on_conflict OnConflict
+	if parser.current.IsTokenType(lex.TOKEN_LEFT_PAREN) {
+		on_conflict.columns = parser.parse_column_list()
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_DO, "Expected DO")
+
+	if parser.match_token(lex.TOKEN_KEYWORD_NOTHING) {
+		return &on_conflict
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_UPDATE, "Expected NOTHING or UPDATE")
+	if on_conflict.columns == nil {
+		panic("ON CONFLICT DO UPDATE requires a conflict target")
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_SET, "Expected SET")
+	on_conflict.do_update = true
+	on_conflict.column_names, on_conflict.column_values = parser.parse_assignments()
+	on_conflict.where = parser.parse_where()
+
+	return &on_conflict
+}
+
+func (parser *Parser) parse_values_rows() [][]Expression {
+	var rows [][]Expression
+
+	for {
+		var row []Expression
+
+		parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+		for {
+			expression := parser.parse_expression()
+			check_plain_expression(expression, "VALUES")
+			row = append(row, expression)
 
 			if parser.match_token(lex.TOKEN_COMMA) {
 				continue
 			}
 
-			if parser.match_token(lex.TOKEN_KEYWORD_FROM) {
-				seen_from = true
+			if parser.match_token(lex.TOKEN_RIGHT_PAREN) {
 				break
 			}
 
 			panic("Expected ',' or ')")
 		}
+
+		if len(rows) > 0 && len(row) != len(rows[0]) {
+			panic("VALUES lists must all be the same length")
+		}
+		rows = append(rows, row)
+
+		if !parser.match_token(lex.TOKEN_COMMA) {
+			return rows
+		}
 	}
+}
+
+func (parser *Parser) parse_select_statement() Statement {
+	return Statement{parser.parse_query(parser.start)}
+}
+
+func (parser *Parser) parse_query(start int) Query {
+	query := parser.parse_compound(start)
 
-	if !seen_from {
-		parser.consume_token(lex.TOKEN_KEYWORD_FROM, "Expected FROM")
+	var order_by []OrderingTerm
+	if parser.match_token(lex.TOKEN_KEYWORD_ORDER) {
+		parser.consume_token(lex.TOKEN_KEYWORD_BY, "Expected BY")
+		order_by = parser.parse_ordering_terms()
 	}
 
-	if !parser.match_token(lex.TOKEN_IDENTIFIER) {
-		panic("Expected identifier")
+	var limit, offset Expression
+	if parser.match_token(lex.TOKEN_KEYWORD_LIMIT) {
+		limit = parser.parse_expression()
+	}
+	if parser.match_token(lex.TOKEN_KEYWORD_OFFSET) {
+		offset = parser.parse_expression()
 	}
-	table_name_token := parser.previous
 
-	content := SelectStatement{NODE_SELECT_STATEMENT, parser.start, columns, table_name_token}
-	return Statement{&content}
+	switch content := query.(type) {
+	case *SelectStatement:
+		content.order_by, content.limit, content.offset = order_by, limit, offset
+	case *CompoundSelect:
+		content.order_by, content.limit, content.offset = order_by, limit, offset
+	}
+
+	if parser.outer_queries == 0 {
+		check_no_tables(query)
+	}
+	check_query(query)
+
+	return query
+}
+
+func (parser *Parser) parse_with(start int) Query {
+	recursive := parser.match_token(lex.TOKEN_KEYWORD_RECURSIVE)
+
+	var tables []CommonTable
+	names := make(map[string]bool)
+	for {
+		parser.consume_identifier("Expected identifier")
+		table := CommonTable{name: parser.previous}
+		if names[table.name.Value()] {
+			panic(fmt.Sprintf("WITH query name %s specified more than once", lex.QuoteIdentifier(table.name.Value())))
+		}
+		names[table.name.Value()] = true
+
+		if parser.current.IsTokenType(lex.TOKEN_LEFT_PAREN) {
+			table.columns = parser.parse_column_list()
+		}
+
+		parser.consume_token(lex.TOKEN_KEYWORD_AS, "Expected AS")
+		parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+		table.query = parser.parse_subquery()
+
+		check_common_table(table, recursive)
+		tables = append(tables, table)
+
+		if !parser.match_token(lex.TOKEN_COMMA) {
+			break
+		}
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_SELECT, "Expected SELECT")
+	query := parser.parse_query(parser.previous.Offset())
+
+	return &WithQuery{NODE_WITH_QUERY, start, recursive, tables, query}
+}
+
+func (parser *Parser) parse_compound(start int) Query {
+	left := parser.parse_intersect(start)
+
+	for {
+		operator := SET_UNION
+		switch {
+		case parser.match_token(lex.TOKEN_KEYWORD_UNION):
+		case parser.match_token(lex.TOKEN_KEYWORD_EXCEPT):
+			operator = SET_EXCEPT
+		default:
+			return left
+		}
+
+		all := parser.parse_set_quantifier()
+		parser.consume_token(lex.TOKEN_KEYWORD_SELECT, "Expected SELECT")
+		right := parser.parse_intersect(parser.previous.Offset())
+
+		left = make_compound(operator, all, left, right)
+	}
+}
+
+func (parser *Parser) parse_intersect(start int) Query {
+	var left Query = parser.parse_select(start)
+
+	for parser.match_token(lex.TOKEN_KEYWORD_INTERSECT) {
+		all := parser.parse_set_quantifier()
+		parser.consume_token(lex.TOKEN_KEYWORD_SELECT, "Expected SELECT")
+		right := parser.parse_select(parser.previous.Offset())
+
+		left = make_compound(SET_INTERSECT, all, left, right)
+	}
+
+	return left
+}
+
+func (parser *Parser) parse_set_quantifier() bool {
+	if parser.match_token(lex.TOKEN_KEYWORD_ALL) {
+		return true
+	}
+
+	parser.match_token(lex.TOKEN_KEYWORD_DISTINCT)
+	return false
+}
+
+func make_compound(operator SetOperator, all bool, left Query, right Query) Query {
+	left_width, left_known := query_width(left)
+	right_width, right_known := query_width(right)
+
+	if left_known && right_known && left_width != right_width {
+		panic(fmt.Sprintf("Each %s query must have the same number of columns", operator))
+	}
+
+	return &CompoundSelect{NODE_COMPOUND_SELECT, left.Pos(), operator, all, left, right, nil, nil, nil}
+}
+
+func (parser *Parser) parse_select(start int) *SelectStatement {
+	distinct := parser.match_token(lex.TOKEN_KEYWORD_DISTINCT)
+	if !distinct {
+		parser.match_token(lex.TOKEN_KEYWORD_ALL)
+	}
+
+	columns := parser.parse_result_columns()
+
+	var from TableReference
+	if parser.match_token(lex.TOKEN_KEYWORD_FROM) {
+		from = parser.parse_from()
+	} else if !parser.is_select_end() {
+		panic("Expected FROM")
+	}
+
+	where := parser.parse_where()
+
+	var group_by []Expression
+	if parser.match_token(lex.TOKEN_KEYWORD_GROUP) {
+		parser.consume_token(lex.TOKEN_KEYWORD_BY, "Expected BY")
+		group_by = parser.parse_expression_list()
+	}
+
+	var having Expression
+	if parser.match_token(lex.TOKEN_KEYWORD_HAVING) {
+		having = parser.parse_expression()
+	}
+
+	return &SelectStatement{NODE_SELECT_STATEMENT, start, distinct, columns, from, where, group_by, having, nil, nil, nil}
+}
+
+func (parser *Parser) is_select_end() bool {
+	switch parser.current.Type() {
+	case lex.TOKEN_SEMI_COLON, lex.TOKEN_RIGHT_PAREN, lex.TOKEN_EOF, lex.TOKEN_KEYWORD_WHERE, lex.TOKEN_KEYWORD_GROUP,
+		lex.TOKEN_KEYWORD_HAVING, lex.TOKEN_KEYWORD_ORDER, lex.TOKEN_KEYWORD_LIMIT, lex.TOKEN_KEYWORD_OFFSET,
+		lex.TOKEN_KEYWORD_UNION, lex.TOKEN_KEYWORD_INTERSECT, lex.TOKEN_KEYWORD_EXCEPT, lex.TOKEN_KEYWORD_ON,
+		lex.TOKEN_KEYWORD_RETURNING:
+		return true
+	default:
+		return false
+	}
+}
+
+func (parser *Parser) parse_from() TableReference {
+	from := parser.parse_joined_table()
+
+	for parser.match_token(lex.TOKEN_COMMA) {
+		right := parser.parse_joined_table()
+		from = &JoinClause{NODE_JOIN_CLAUSE, from.Pos(), JOIN_CROSS, from, right, nil, nil}
+	}
+
+	check_table_names(from, make(map[string]bool))
+
+	return from
+}
+
+func (parser *Parser) parse_joined_table() TableReference {
+	left := parser.parse_table_primary()
+
+	for {
+		join_type, ok := parser.parse_join_type()
+		if !ok {
+			return left
+		}
+
+		right := parser.parse_table_primary()
+		join := JoinClause{NODE_JOIN_CLAUSE, left.Pos(), join_type, left, right, nil, nil}
+
+		switch {
+		case join_type == JOIN_CROSS:
+		case parser.match_token(lex.TOKEN_KEYWORD_ON):
+			join.on = parser.parse_expression()
+			check_no_aggregates(join.on, "JOIN conditions")
+		case parser.match_token(lex.TOKEN_KEYWORD_USING):
+			join.using = parser.parse_column_list()
+		default:
+			panic("Expected ON or USING")
+		}
+
+		left = &join
+	}
+}
+
+func (parser *Parser) parse_join_type() (JoinType, bool) {
+	join_type := JOIN_INNER
+
+	switch {
+	case parser.match_token(lex.TOKEN_KEYWORD_JOIN):
+		return JOIN_INNER, true
+	case parser.match_token(lex.TOKEN_KEYWORD_CROSS):
+		join_type = JOIN_CROSS
+	case parser.match_token(lex.TOKEN_KEYWORD_INNER):
+		join_type = JOIN_INNER
+	case parser.match_token(lex.TOKEN_KEYWORD_LEFT):
+		join_type = JOIN_LEFT
+		parser.match_token(lex.TOKEN_KEYWORD_OUTER)
+	case parser.match_token(lex.TOKEN_KEYWORD_RIGHT):
+		join_type = JOIN_RIGHT
+		parser.match_token(lex.TOKEN_KEYWORD_OUTER)
+	case parser.match_token(lex.TOKEN_KEYWORD_FULL):
+		join_type = JOIN_FULL
+		parser.match_token(lex.TOKEN_KEYWORD_OUTER)
+	default:
+		return join_type, false
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_JOIN, "Expected JOIN")
+	return join_type, true
+}
+
+func (parser *Parser) parse_table_primary() TableReference {
+	if parser.match_token(lex.TOKEN_LEFT_PAREN) {
+		start := parser.previous.Offset()
+		query := parser.parse_subquery()
+
+		alias := parser.parse_alias()
+		if !alias.IsTokenType(lex.TOKEN_IDENTIFIER) {
+			panic("Subquery in FROM must have an alias")
+		}
+
+		return &SubqueryTable{NODE_SUBQUERY_TABLE, start, query, alias}
+	}
+
+	parser.consume_identifier("Expected identifier")
+	table := TableName{NODE_TABLE_NAME, parser.previous.Offset(), parser.previous, lex.Token{}}
+	table.alias = parser.parse_alias()
+
+	return &table
+}
+
+func (parser *Parser) parse_alias() lex.Token {
+	if parser.match_token(lex.TOKEN_KEYWORD_AS) {
+		parser.consume_identifier("Expected alias")
+		return parser.previous
+	}
+
+	switch parser.current.Type() {
+	case lex.TOKEN_KEYWORD_LEFT, lex.TOKEN_KEYWORD_RIGHT, lex.TOKEN_KEYWORD_FULL:
+		return lex.Token{}
+	}
+
+	if parser.match_identifier() {
+		return parser.previous
+	}
+
+	return lex.Token{}
+}
+
+func (parser *Parser) parse_expression_subquery() Query {
+	parser.outer_queries += 1
+	defer func() { parser.outer_queries -= 1 }()
+
+	return parser.parse_subquery()
+}
+
+func (parser *Parser) parse_subquery() Query {
+	var query Query
+	if parser.match_token(lex.TOKEN_KEYWORD_WITH) {
+		query = parser.parse_with(parser.previous.Offset())
+	} else {
+		parser.consume_token(lex.TOKEN_KEYWORD_SELECT, "Expected SELECT")
+		query = parser.parse_query(parser.previous.Offset())
+	}
+	parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ')'")
+
+	return query
+}
+
+func (parser *Parser) parse_expression_list() []Expression {
+	var expressions []Expression
+
+	for {
+		expressions = append(expressions, parser.parse_expression())
+
+		if !parser.match_token(lex.TOKEN_COMMA) {
+			return expressions
+		}
+	}
+}
+
+func (parser *Parser) parse_ordering_terms() []OrderingTerm {
+	var terms []OrderingTerm
+
+	for {
+		term := OrderingTerm{expression: parser.parse_expression()}
+
+		if parser.match_token(lex.TOKEN_KEYWORD_DESC) {
+			term.descending = true
+		} else {
+			parser.match_token(lex.TOKEN_KEYWORD_ASC)
+		}
+
+		term.nulls_first = term.descending
+		if parser.match_token(lex.TOKEN_KEYWORD_NULLS) {
+			if parser.match_token(lex.TOKEN_KEYWORD_FIRST) {
+				term.nulls_first = true
+			} else {
+				parser.consume_token(lex.TOKEN_KEYWORD_LAST, "Expected FIRST or LAST")
+				term.nulls_first = false
+			}
+		}
+
+		terms = append(terms, term)
+
+		if !parser.match_token(lex.TOKEN_COMMA) {
+			return terms
+		}
+	}
+}
+
+func (parser *Parser) parse_result_columns() []ResultColumn {
+	var columns []ResultColumn
+
+	for {
+		if parser.match_token(lex.TOKEN_ASTERISK) {
+			columns = append(columns, ResultColumn{&StarExpression{NODE_STAR_EXPRESSION, parser.previous.Offset(), lex.Token{}}, lex.Token{}})
+		} else {
+			column := ResultColumn{expression: parser.parse_expression()}
+			if _, ok := column.expression.(*StarExpression); !ok {
+				column.alias = parser.parse_alias()
+			}
+
+			columns = append(columns, column)
+		}
+
+		if !parser.match_token(lex.TOKEN_COMMA) {
+			return columns
+		}
+	}
+}
+
+func (parser *Parser) parse_where() Expression {
+	if parser.match_token(lex.TOKEN_KEYWORD_WHERE) {
+		where := parser.parse_expression()
+		check_plain_expression(where, "WHERE")
+		return where
+	}
+
+	return nil
+}
+
+func (parser *Parser) parse_returning() []ResultColumn {
+	if parser.match_token(lex.TOKEN_KEYWORD_RETURNING) {
+		returning := parser.parse_result_columns()
+		for _, column := range returning {
+			check_plain_expression(column.expression, "RETURNING")
+		}
+
+		return returning
+	}
+
+	return nil
+}
+
+func (parser *Parser) parse_update_statement() Statement {
+	parser.consume_identifier("Expected identifier")
+	table_name_token := parser.previous
+
+	parser.consume_token(lex.TOKEN_KEYWORD_SET, "Expected SET")
+	column_names, column_values := parser.parse_assignments()
+
+	where := parser.parse_where()
+	returning := parser.parse_returning()
+
+	content := UpdateStatement{NODE_UPDATE_STATEMENT, parser.start, table_name_token, column_names, column_values, where, returning}
+	return Statement{&content}
+}
+
+func (parser *Parser) parse_assignments() ([]lex.Token, []Expression) {
+	var column_names []lex.Token
+	var column_values []Expression
+
+	for {
+		parser.consume_identifier("Expected identifier")
+		column_names = append(column_names, parser.previous)
+
+		parser.consume_token(lex.TOKEN_EQUAL, "Expected '='")
+		value := parser.parse_expression()
+		check_plain_expression(value, "UPDATE")
+		column_values = append(column_values, value)
+
+		if !parser.match_token(lex.TOKEN_COMMA) {
+			return column_names, column_values
+		}
+	}
+}
+
+func (parser *Parser) parse_delete_statement() Statement {
+	parser.consume_token(lex.TOKEN_KEYWORD_FROM, "Expected FROM")
+
+	parser.consume_identifier("Expected identifier")
+	table_name_token := parser.previous
+
+	where := parser.parse_where()
+	returning := parser.parse_returning()
+
+	content := DeleteStatement{NODE_DELETE_STATEMENT, parser.start, table_name_token, where, returning}
+	return Statement{&content}
+}
+
+func (parser *Parser) parse_expression() Expression {
+	return parser.parse_or()
+}
+
+func (parser *Parser) parse_or() Expression {
+	left := parser.parse_and()
+
+	for parser.match_token(lex.TOKEN_KEYWORD_OR) {
+		operator := parser.previous
+		right := parser.parse_and()
+		left = &BinaryExpression{NODE_BINARY_EXPRESSION, left.Pos(), operator, left, right}
+	}
+
+	return left
+}
+
+func (parser *Parser) parse_and() Expression {
+	left := parser.parse_not()
+
+	for parser.match_token(lex.TOKEN_KEYWORD_AND) {
+		operator := parser.previous
+		right := parser.parse_not()
+		left = &BinaryExpression{NODE_BINARY_EXPRESSION, left.Pos(), operator, left, right}
+	}
+
+	return left
+}
+
+func (parser *Parser) parse_not() Expression {
+	if parser.match_token(lex.TOKEN_KEYWORD_NOT) {
+		operator := parser.previous
+		operand := parser.parse_not()
+
+		return &UnaryExpression{NODE_UNARY_EXPRESSION, operator.Offset(), operator, operand}
+	}
+
+	return parser.parse_is()
+}
+
+func (parser *Parser) parse_is() Expression {
+	operand := parser.parse_comparison()
+
+	for parser.match_token(lex.TOKEN_KEYWORD_IS) {
+		negated := parser.match_token(lex.TOKEN_KEYWORD_NOT)
+		parser.consume_token(lex.TOKEN_KEYWORD_NULL, "Expected NULL")
+
+		operand = &IsNullExpression{NODE_IS_NULL_EXPRESSION, operand.Pos(), operand, negated}
+	}
+
+	return operand
+}
+
+func (parser *Parser) parse_comparison() Expression {
+	left := parser.parse_concat()
+
+	negated := false
+	if parser.current.IsTokenType(lex.TOKEN_KEYWORD_NOT) && is_predicate(parser.peek_token()) {
+		parser.advance()
+		negated = true
+	}
+
+	switch {
+	case parser.match_token(lex.TOKEN_KEYWORD_IN):
+		return parser.parse_in(left, negated)
+	case parser.match_token(lex.TOKEN_KEYWORD_BETWEEN):
+		low := parser.parse_concat()
+		parser.consume_token(lex.TOKEN_KEYWORD_AND, "Expected AND")
+		high := parser.parse_concat()
+
+		return &BetweenExpression{NODE_BETWEEN_EXPRESSION, left.Pos(), left, low, high, negated}
+	case parser.match_token(lex.TOKEN_KEYWORD_LIKE) || parser.match_token(lex.TOKEN_KEYWORD_ILIKE) ||
+		parser.match_token(lex.TOKEN_KEYWORD_GLOB) || parser.match_token(lex.TOKEN_KEYWORD_REGEXP):
+		return parser.parse_pattern(left, negated)
+	}
+
+	if !parser.current.IsComparisonOperator() {
+		return left
+	}
+
+	parser.advance()
+	operator := parser.previous
+	right := parser.parse_concat()
+
+	if parser.current.IsComparisonOperator() {
+		panic("Comparison operators cannot be chained")
+	}
+
+	return &BinaryExpression{NODE_BINARY_EXPRESSION, left.Pos(), operator, left, right}
+}
+
+func is_predicate(token lex.Token) bool {
+	switch token.Type() {
+	case lex.TOKEN_KEYWORD_IN, lex.TOKEN_KEYWORD_BETWEEN, lex.TOKEN_KEYWORD_LIKE, lex.TOKEN_KEYWORD_ILIKE, lex.TOKEN_KEYWORD_GLOB, lex.TOKEN_KEYWORD_REGEXP:
+		return true
+	default:
+		return false
+	}
+}
+
+func (parser *Parser) parse_in(operand Expression, negated bool) Expression {
+	parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+
+	if parser.current.IsTokenType(lex.TOKEN_KEYWORD_SELECT) || parser.current.IsTokenType(lex.TOKEN_KEYWORD_WITH) {
+		return &InExpression{NODE_IN_EXPRESSION, operand.Pos(), operand, parser.parse_expression_subquery(), nil, negated}
+	}
+
+	values := parser.parse_expression_list()
+	parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ',' or ')")
+
+	return &InExpression{NODE_IN_EXPRESSION, operand.Pos(), operand, nil, values, negated}
+}
+
+func (parser *Parser) parse_pattern(operand Expression, negated bool) Expression {
+	operator := parser.previous
+	pattern := parser.parse_concat()
+
+	var escape Expression
+	if parser.match_token(lex.TOKEN_KEYWORD_ESCAPE) {
+		if !operator.IsTokenType(lex.TOKEN_KEYWORD_LIKE) && !operator.IsTokenType(lex.TOKEN_KEYWORD_ILIKE) {
+			panic("ESCAPE is only allowed with LIKE and ILIKE")
+		}
+
+		escape = parser.parse_concat()
+	}
+
+	check_pattern(operator, pattern, escape)
+
+	return &PatternExpression{NODE_PATTERN_EXPRESSION, operand.Pos(), operator, operand, pattern, escape, negated}
+}
+
+func check_pattern(operator lex.Token, pattern Expression, escape Expression) {
+	text, ok := text_literal(pattern)
+	if !ok {
+		return
+	}
+
+	var err error
+	switch operator.Type() {
+	case lex.TOKEN_KEYWORD_GLOB:
+		_, err = functions.Glob(types.NewText(""), text)
+	case lex.TOKEN_KEYWORD_REGEXP:
+		_, err = functions.Regexp(types.NewText(""), text)
+	default:
+		escape_text := types.NewText(functions.LIKE_ESCAPE)
+		if escape != nil {
+			if escape_text, ok = text_literal(escape); !ok {
+				return
+			}
+		}
+
+		_, err = functions.Like(types.NewText(""), text, escape_text, false)
+	}
+
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func text_literal(expression Expression) (types.Value, bool) {
+	literal, ok := expression.(*LiteralExpression)
+	if !ok || literal._type != NODE_TEXT_VALUE {
+		return types.Value{}, false
+	}
+
+	return types.NewText(literal.value.Value()), true
+}
+
+func (parser *Parser) parse_concat() Expression {
+	left := parser.parse_additive()
+
+	for parser.match_token(lex.TOKEN_CONCAT) {
+		operator := parser.previous
+		right := parser.parse_additive()
+		left = &BinaryExpression{NODE_BINARY_EXPRESSION, left.Pos(), operator, left, right}
+	}
+
+	return left
+}
+
+func (parser *Parser) parse_additive() Expression {
+	left := parser.parse_multiplicative()
+
+	for parser.match_token(lex.TOKEN_PLUS) || parser.match_token(lex.TOKEN_MINUS) {
+		operator := parser.previous
+		right := parser.parse_multiplicative()
+		left = &BinaryExpression{NODE_BINARY_EXPRESSION, left.Pos(), operator, left, right}
+	}
+
+	return left
+}
+
+func (parser *Parser) parse_multiplicative() Expression {
+	left := parser.parse_unary()
+
+	for parser.match_token(lex.TOKEN_ASTERISK) || parser.match_token(lex.TOKEN_SLASH) || parser.match_token(lex.TOKEN_PERCENT) {
+		operator := parser.previous
+		right := parser.parse_unary()
+		left = &BinaryExpression{NODE_BINARY_EXPRESSION, left.Pos(), operator, left, right}
+	}
+
+	return left
+}
+
+func (parser *Parser) parse_unary() Expression {
+	if parser.match_token(lex.TOKEN_MINUS) || parser.match_token(lex.TOKEN_PLUS) {
+		operator := parser.previous
+
+		if operator.IsTokenType(lex.TOKEN_MINUS) && parser.match_token(lex.TOKEN_LITERAL_INTEGER) {
+			token := parser.previous
+			parser.check_literal(types.ParseInteger("-" + token.Value()))
+			operand := &LiteralExpression{NODE_INTEGER_VALUE, token.Offset(), token}
+
+			return &UnaryExpression{NODE_UNARY_EXPRESSION, operator.Offset(), operator, operand}
+		}
+
+		operand := parser.parse_unary()
+
+		return &UnaryExpression{NODE_UNARY_EXPRESSION, operator.Offset(), operator, operand}
+	}
+
+	return parser.parse_primary()
+}
+
+func (parser *Parser) parse_primary() Expression {
+	if parser.current.IsUnreserved() && !parser.is_typed_literal() {
+		parser.current = parser.current.AsIdentifier()
+	}
+
+	parser.advance()
+	token := parser.previous
+
+	switch {
+	case token.IsTokenType(lex.TOKEN_LITERAL_INTEGER):
+		parser.check_literal(types.ParseInteger(token.Value()))
+		return &LiteralExpression{NODE_INTEGER_VALUE, token.Offset(), token}
+	case token.IsTokenType(lex.TOKEN_LITERAL_REAL):
+		parser.check_literal(types.ParseReal(token.Value()))
+		return &LiteralExpression{NODE_REAL_VALUE, token.Offset(), token}
+	case token.IsTokenType(lex.TOKEN_LITERAL_TEXT):
+		return &LiteralExpression{NODE_TEXT_VALUE, token.Offset(), token}
+	case token.IsTokenType(lex.TOKEN_LITERAL_BLOB):
+		return &LiteralExpression{NODE_BLOB_VALUE, token.Offset(), token}
+	case token.IsTokenType(lex.TOKEN_KEYWORD_TRUE) || token.IsTokenType(lex.TOKEN_KEYWORD_FALSE):
+		return &LiteralExpression{NODE_BOOLEAN_VALUE, token.Offset(), token}
+	case token.IsTokenType(lex.TOKEN_KEYWORD_NULL):
+		return &LiteralExpression{NODE_NULL_VALUE, token.Offset(), token}
+	case token.IsTokenType(lex.TOKEN_IDENTIFIER):
+		quoted := parser.is_quoted(token)
+		if !quoted && token.Value() == "extract" && parser.match_token(lex.TOKEN_LEFT_PAREN) {
+			return parser.parse_extract(token)
+		}
+
+		if parser.match_token(lex.TOKEN_LEFT_PAREN) {
+			return parser.parse_function_call(token)
+		}
+
+		if !quoted && niladic_functions[token.Value()] && !parser.current.IsTokenType(lex.TOKEN_DOT) {
+			return &FunctionExpression{_type: NODE_FUNCTION_EXPRESSION, start: token.Offset(), name: token}
+		}
+
+		if parser.match_token(lex.TOKEN_DOT) {
+			if parser.match_token(lex.TOKEN_ASTERISK) {
+				return &StarExpression{NODE_STAR_EXPRESSION, token.Offset(), token}
+			}
+
+			parser.consume_identifier("Expected column name")
+			return &ColumnExpression{NODE_COLUMN_EXPRESSION, token.Offset(), token, parser.previous}
+		}
+
+		return &ColumnExpression{NODE_COLUMN_EXPRESSION, token.Offset(), lex.Token{}, token}
+	case token.IsTokenType(lex.TOKEN_PARAMETER):
+		return parser.parse_parameter(token)
+	case token.IsTokenType(lex.TOKEN_LEFT_PAREN) && (parser.current.IsTokenType(lex.TOKEN_KEYWORD_SELECT) || parser.current.IsTokenType(lex.TOKEN_KEYWORD_WITH)):
+		return &SubqueryExpression{NODE_SUBQUERY_EXPRESSION, token.Offset(), parser.parse_expression_subquery()}
+	case token.IsTokenType(lex.TOKEN_KEYWORD_EXISTS):
+		parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+		return &ExistsExpression{NODE_EXISTS_EXPRESSION, token.Offset(), parser.parse_expression_subquery()}
+	case token.IsTokenType(lex.TOKEN_LEFT_PAREN):
+		expression := parser.parse_expression()
+		parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ')'")
+
+		return expression
+	case token.IsTokenType(lex.TOKEN_KEYWORD_CASE):
+		return parser.parse_case(token)
+	case token.IsTokenType(lex.TOKEN_KEYWORD_CAST):
+		return parser.parse_cast(token)
+	case token.IsTokenType(lex.TOKEN_KEYWORD_DATE):
+		return parser.parse_typed_literal(NODE_DATE_VALUE, token, types.ParseDate)
+	case token.IsTokenType(lex.TOKEN_KEYWORD_TIME):
+		return parser.parse_typed_literal(NODE_TIME_VALUE, token, types.ParseTime)
+	case token.IsTokenType(lex.TOKEN_KEYWORD_TIMESTAMP):
+		return parser.parse_typed_literal(NODE_TIMESTAMP_VALUE, token, types.ParseTimestamp)
+	case token.IsTokenType(lex.TOKEN_KEYWORD_INTERVAL):
+		return parser.parse_typed_literal(NODE_INTERVAL_VALUE, token, types.ParseInterval)
+	}
+
+	panic("Expected expression")
+}
+
+func (parser *Parser) is_quoted(token lex.Token) bool {
+	switch rune(parser.source[token.Offset()]) {
+	case lex.SYMBOL_DOUBLE_QUOTE, lex.SYMBOL_BACKTICK:
+		return true
+	}
+
+	return false
+}
+
+func (parser *Parser) parse_extract(name lex.Token) Expression {
+	parser.consume_identifier("Expected field name")
+	field := parser.previous
+	if !functions.IsDateField(field.Value()) {
+		panic(fmt.Sprintf("Unit '%s' not recognized", field.Value()))
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_FROM, "Expected FROM")
+	source := parser.parse_expression()
+	parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ')'")
+
+	unit := &LiteralExpression{NODE_TEXT_VALUE, field.Offset(), lex.MakeToken(lex.TOKEN_LITERAL_TEXT, field.Value(), field.Offset())}
+	return &FunctionExpression{_type: NODE_FUNCTION_EXPRESSION, start: name.Offset(), name: name, arguments: []Expression{unit, source}}
+}
+
+func (parser *Parser) parse_function_call(name lex.Token) Expression {
+	function := FunctionExpression{_type: NODE_FUNCTION_EXPRESSION, start: name.Offset(), name: name}
+	function.distinct = parser.match_token(lex.TOKEN_KEYWORD_DISTINCT)
+
+	switch {
+	case !function.distinct && parser.match_token(lex.TOKEN_ASTERISK):
+		function.star = true
+		parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ')'")
+	case !function.distinct && parser.match_token(lex.TOKEN_RIGHT_PAREN):
+	default:
+		function.arguments = parser.parse_expression_list()
+		parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ',' or ')")
+	}
+
+	aggregate := functions.IsAggregate(name.Value())
+
+	if function.star && name.Value() != "count" {
+		panic(fmt.Sprintf("%s(*) is not allowed", name.Value()))
+	}
+
+	if function.distinct && !aggregate {
+		panic(fmt.Sprintf("DISTINCT specified, but %s is not an aggregate function", name.Value()))
+	}
+
+	if !function.star {
+		if err := functions.CheckArguments(name.Value(), len(function.arguments)); err != nil {
+			panic(err.Error())
+		}
+	}
+
+	if parser.match_token(lex.TOKEN_KEYWORD_OVER) {
+		if !aggregate && !functions.IsWindow(name.Value()) {
+			panic(fmt.Sprintf("OVER specified, but %s is not a window function nor an aggregate function", name.Value()))
+		}
+
+		if function.distinct {
+			panic("DISTINCT is not implemented for window functions")
+		}
+
+		return &WindowExpression{NODE_WINDOW_EXPRESSION, name.Offset(), &function, parser.parse_window_definition()}
+	}
+
+	if functions.IsWindow(name.Value()) {
+		panic(fmt.Sprintf("Window function %s requires an OVER clause", name.Value()))
+	}
+
+	return &function
+}
+
+func (parser *Parser) parse_window_definition() WindowDefinition {
+	parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+
+	var window WindowDefinition
+	if parser.match_token(lex.TOKEN_KEYWORD_PARTITION) {
+		parser.consume_token(lex.TOKEN_KEYWORD_BY, "Expected BY")
+		window.partition_by = parser.parse_expression_list()
+	}
+
+	if parser.match_token(lex.TOKEN_KEYWORD_ORDER) {
+		parser.consume_token(lex.TOKEN_KEYWORD_BY, "Expected BY")
+		window.order_by = parser.parse_ordering_terms()
+	}
+
+	window.frame = parser.parse_window_frame(len(window.order_by))
+	parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ')'")
+
+	for _, expression := range window.partition_by {
+		check_no_windows(expression, "window definitions")
+	}
+	for _, term := range window.order_by {
+		check_no_windows(term.expression, "window definitions")
+	}
+
+	return window
+}
+
+func (parser *Parser) parse_window_frame(ordering_terms int) WindowFrame {
+	frame := WindowFrame{functions.FRAME_RANGE, FrameBound{kind: functions.BOUND_UNBOUNDED_PRECEDING}, FrameBound{kind: functions.BOUND_CURRENT_ROW}}
+
+	switch {
+	case parser.match_token(lex.TOKEN_KEYWORD_ROWS):
+		frame.mode = functions.FRAME_ROWS
+	case parser.match_token(lex.TOKEN_KEYWORD_RANGE):
+	default:
+		return frame
+	}
+
+	if parser.match_token(lex.TOKEN_KEYWORD_BETWEEN) {
+		frame.start = parser.parse_frame_bound(frame.mode)
+		parser.consume_token(lex.TOKEN_KEYWORD_AND, "Expected AND")
+		frame.end = parser.parse_frame_bound(frame.mode)
+	} else {
+		frame.start = parser.parse_frame_bound(frame.mode)
+	}
+
+	switch {
+	case frame.start.kind == functions.BOUND_UNBOUNDED_FOLLOWING:
+		panic("Frame start cannot be UNBOUNDED FOLLOWING")
+	case frame.end.kind == functions.BOUND_UNBOUNDED_PRECEDING:
+		panic("Frame end cannot be UNBOUNDED PRECEDING")
+	case frame.start.kind == functions.BOUND_CURRENT_ROW && frame.end.kind < frame.start.kind:
+		panic("Frame starting from current row cannot have preceding rows")
+	case frame.start.kind == functions.BOUND_FOLLOWING && frame.end.kind < frame.start.kind:
+		panic("Frame starting from following row cannot have preceding rows")
+	}
+
+	if frame.mode == functions.FRAME_RANGE && (frame.start.offset != nil || frame.end.offset != nil) && ordering_terms != 1 {
+		panic("RANGE with offset PRECEDING/FOLLOWING requires exactly one ORDER BY column")
+	}
+
+	return frame
+}
+
+func (parser *Parser) parse_frame_bound(mode functions.FrameMode) FrameBound {
+	switch {
+	case parser.match_token(lex.TOKEN_KEYWORD_UNBOUNDED):
+		if parser.match_token(lex.TOKEN_KEYWORD_PRECEDING) {
+			return FrameBound{kind: functions.BOUND_UNBOUNDED_PRECEDING}
+		}
+
+		parser.consume_token(lex.TOKEN_KEYWORD_FOLLOWING, "Expected PRECEDING or FOLLOWING")
+		return FrameBound{kind: functions.BOUND_UNBOUNDED_FOLLOWING}
+	case parser.match_token(lex.TOKEN_KEYWORD_CURRENT):
+		parser.consume_token(lex.TOKEN_KEYWORD_ROW, "Expected ROW")
+		return FrameBound{kind: functions.BOUND_CURRENT_ROW}
+	}
+
+	offset := parser.parse_concat()
+	walk_expression(offset, func(e Expression) bool {
+		switch e.(type) {
+		case *ColumnExpression, *SubqueryExpression, *ExistsExpression:
+			panic(fmt.Sprintf("Argument of %s must not contain variables", mode))
+		}
+
+		return true
+	})
+	check_plain_expression(offset, mode.String())
+
+	if parser.match_token(lex.TOKEN_KEYWORD_PRECEDING) {
+		return FrameBound{functions.BOUND_PRECEDING, offset}
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_FOLLOWING, "Expected PRECEDING or FOLLOWING")
+	return FrameBound{functions.BOUND_FOLLOWING, offset}
+}
+
+func (parser *Parser) parse_case(token lex.Token) Expression {
+	content := CaseExpression{_type: NODE_CASE_EXPRESSION, start: token.Offset()}
+
+	if parser.current.IsTokenType(lex.TOKEN_KEYWORD_END) {
+		panic("Expected expression")
+	}
+
+	if !parser.current.IsTokenType(lex.TOKEN_KEYWORD_WHEN) {
+		content.operand = parser.parse_expression()
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_WHEN, "Expected WHEN")
+	for {
+		condition := parser.parse_expression()
+		parser.consume_token(lex.TOKEN_KEYWORD_THEN, "Expected THEN")
+		content.whens = append(content.whens, WhenClause{condition, parser.parse_expression()})
+
+		if !parser.match_token(lex.TOKEN_KEYWORD_WHEN) {
+			break
+		}
+	}
+
+	if parser.match_token(lex.TOKEN_KEYWORD_ELSE) {
+		content.else_result = parser.parse_expression()
+	}
+
+	parser.consume_token(lex.TOKEN_KEYWORD_END, "Expected END")
+
+	return &content
+}
+
+func (parser *Parser) parse_cast(token lex.Token) Expression {
+	parser.consume_token(lex.TOKEN_LEFT_PAREN, "Expected '('")
+	operand := parser.parse_expression()
+	parser.consume_token(lex.TOKEN_KEYWORD_AS, "Expected AS")
+	data_type := parser.parse_data_type()
+	parser.consume_token(lex.TOKEN_RIGHT_PAREN, "Expected ')'")
+
+	return &CastExpression{NODE_CAST_EXPRESSION, token.Offset(), operand, data_type}
+}
+
+func (parser *Parser) parse_parameter(token lex.Token) Expression {
+	positional := token.Value() == ""
+	if (positional && parser.numbered) || (!positional && parser.parameters > 0) {
+		panic("Cannot mix ? and $n parameters in one statement")
+	}
+
+	if positional {
+		parser.parameters += 1
+		return &ParameterExpression{NODE_PARAMETER_EXPRESSION, token.Offset(), parser.parameters}
+	}
+
+	index, err := strconv.Atoi(token.Value())
+	if err != nil || index < 1 {
+		panic(fmt.Sprintf("There is no parameter $%s", token.Value()))
+	}
+
+	parser.numbered = true
+	return &ParameterExpression{NODE_PARAMETER_EXPRESSION, token.Offset(), index}
+}
+
+func (parser *Parser) is_typed_literal() bool {
+	return parser.current.IsDataType() && parser.peek_token().IsValueType()
+}
+
+func (parser *Parser) parse_typed_literal(node_type NodeType, type_token lex.Token, parse func(string) (types.Value, error)) Expression {
+	parser.consume_token(lex.TOKEN_LITERAL_TEXT, "Expected text literal")
+	token := parser.previous
+	parser.check_literal(parse(token.Value()))
+
+	return &LiteralExpression{node_type, type_token.Offset(), token}
+}
+
+func (parser *Parser) check_literal(_ types.Value, err error) {
+	if err != nil {
+		panic(err.Error())
+	}
 }
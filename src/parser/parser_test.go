@@ -1,12 +1,22 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/JamesErrington/tasiadb/src/functions"
 	lex "github.com/JamesErrington/tasiadb/src/lexer"
+	"github.com/JamesErrington/tasiadb/src/types"
 	"github.com/stretchr/testify/assert"
 )
 
+func AssertParseError(t *testing.T, source string, message string) {
+	parser := NewParser(source)
+	parser.advance()
+
+	assert.PanicsWithValue(t, message, func() { parser.parse_statement() }, source)
+}
+
 func TestParseCreateTableSingleColumn(t *testing.T) {
 	parser := NewParser("CREATE TABLE t (c_1 NUMBER);")
 	result := parser.Parse()
@@ -18,7 +28,9 @@ func TestParseCreateTableSingleColumn(t *testing.T) {
 		0,
 		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 13),
 		[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_1", 16)},
-		[]lex.Token{lex.MakeToken(lex.TOKEN_KEYWORD_NUMBER, "", 20)},
+		[]DataType{DataType{lex.MakeToken(lex.TOKEN_KEYWORD_NUMBER, "", 20), nil}},
+		[][]Constraint{nil},
+		nil,
 	}, content)
 }
 
@@ -37,11 +49,13 @@ func TestParseCreateTableMultiColumn(t *testing.T) {
 			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_2", 28),
 			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_3", 37),
 		},
-		[]lex.Token{
-			lex.MakeToken(lex.TOKEN_KEYWORD_NUMBER, "", 20),
-			lex.MakeToken(lex.TOKEN_KEYWORD_TEXT, "", 32),
-			lex.MakeToken(lex.TOKEN_KEYWORD_BOOLEAN, "", 41),
+		[]DataType{
+			DataType{lex.MakeToken(lex.TOKEN_KEYWORD_NUMBER, "", 20), nil},
+			DataType{lex.MakeToken(lex.TOKEN_KEYWORD_TEXT, "", 32), nil},
+			DataType{lex.MakeToken(lex.TOKEN_KEYWORD_BOOLEAN, "", 41), nil},
 		},
+		[][]Constraint{nil, nil, nil},
+		nil,
 	}, content)
 }
 
@@ -56,7 +70,10 @@ func TestParseInsertSingleColumn(t *testing.T) {
 		0,
 		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 12),
 		[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_1", 15)},
-		[]lex.Token{lex.MakeToken(lex.TOKEN_LITERAL_NUMBER, "10.5", 28)},
+		[][]Expression{{&LiteralExpression{NODE_REAL_VALUE, 28, lex.MakeToken(lex.TOKEN_LITERAL_REAL, "10.5", 28)}}},
+		nil,
+		nil,
+		nil,
 	}, content)
 }
 
@@ -75,11 +92,14 @@ func TestParseInsertMultiColumn(t *testing.T) {
 			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_2", 20),
 			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_3", 24),
 		},
-		[]lex.Token{
-			lex.MakeToken(lex.TOKEN_LITERAL_NUMBER, "10.5", 37),
-			lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "Hello", 43),
-			lex.MakeToken(lex.TOKEN_KEYWORD_FALSE, "", 51),
-		},
+		[][]Expression{{
+			&LiteralExpression{NODE_REAL_VALUE, 37, lex.MakeToken(lex.TOKEN_LITERAL_REAL, "10.5", 37)},
+			&LiteralExpression{NODE_TEXT_VALUE, 43, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "Hello", 43)},
+			&LiteralExpression{NODE_BOOLEAN_VALUE, 51, lex.MakeToken(lex.TOKEN_KEYWORD_FALSE, "", 51)},
+		}},
+		nil,
+		nil,
+		nil,
 	}, content)
 }
 
@@ -94,12 +114,116 @@ func TestParseInsertImplicitColumn(t *testing.T) {
 		0,
 		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 12),
 		nil,
-		[]lex.Token{
-			lex.MakeToken(lex.TOKEN_LITERAL_NUMBER, "10.5", 22),
-		},
+		[][]Expression{{
+			&LiteralExpression{NODE_REAL_VALUE, 22, lex.MakeToken(lex.TOKEN_LITERAL_REAL, "10.5", 22)},
+		}},
+		nil,
+		nil,
+		nil,
+	}, content)
+}
+
+func TestParseInsertNumericLiterals(t *testing.T) {
+	parser := NewParser("INSERT INTO t VALUES (-1, +2.5e3, - -0x1F);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Equal(t, &InsertStatement{
+		NODE_INSERT_STATEMENT,
+		0,
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 12),
+		nil,
+		[][]Expression{{
+			&UnaryExpression{
+				NODE_UNARY_EXPRESSION, 22, lex.MakeToken(lex.TOKEN_MINUS, "", 22),
+				&LiteralExpression{NODE_INTEGER_VALUE, 23, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 23)},
+			},
+			&UnaryExpression{
+				NODE_UNARY_EXPRESSION, 26, lex.MakeToken(lex.TOKEN_PLUS, "", 26),
+				&LiteralExpression{NODE_REAL_VALUE, 27, lex.MakeToken(lex.TOKEN_LITERAL_REAL, "2.5e3", 27)},
+			},
+			&UnaryExpression{
+				NODE_UNARY_EXPRESSION, 34, lex.MakeToken(lex.TOKEN_MINUS, "", 34),
+				&UnaryExpression{
+					NODE_UNARY_EXPRESSION, 36, lex.MakeToken(lex.TOKEN_MINUS, "", 36),
+					&LiteralExpression{NODE_INTEGER_VALUE, 37, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "0x1F", 37)},
+				},
+			},
+		}},
+		nil,
+		nil,
+		nil,
 	}, content)
 }
 
+func TestParseCreateTableTypes(t *testing.T) {
+	parser := NewParser("CREATE TABLE t (a INTEGER, b REAL, c DECIMAL(10, 2), d DECIMAL(5), e DECIMAL, f BLOB, g DATE, h TIME, i TIMESTAMP);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*CreateTableStatement)
+	assert.Equal(t, []DataType{
+		{lex.MakeToken(lex.TOKEN_KEYWORD_INTEGER, "", 18), nil},
+		{lex.MakeToken(lex.TOKEN_KEYWORD_REAL, "", 29), nil},
+		{lex.MakeToken(lex.TOKEN_KEYWORD_DECIMAL, "", 37), []lex.Token{
+			lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "10", 45), lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "2", 49),
+		}},
+		{lex.MakeToken(lex.TOKEN_KEYWORD_DECIMAL, "", 55), []lex.Token{lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "5", 63)}},
+		{lex.MakeToken(lex.TOKEN_KEYWORD_DECIMAL, "", 69), nil},
+		{lex.MakeToken(lex.TOKEN_KEYWORD_BLOB, "", 80), nil},
+		{lex.MakeToken(lex.TOKEN_KEYWORD_DATE, "", 88), nil},
+		{lex.MakeToken(lex.TOKEN_KEYWORD_TIME, "", 96), nil},
+		{lex.MakeToken(lex.TOKEN_KEYWORD_TIMESTAMP, "", 104), nil},
+	}, content.column_types)
+}
+
+func TestParseCreateTableTypeErrors(t *testing.T) {
+	AssertParseError(t, "CREATE TABLE t (a DECIMAL(0));", "DECIMAL precision must be between 1 and 38")
+	AssertParseError(t, "CREATE TABLE t (a DECIMAL(39, 2));", "DECIMAL precision must be between 1 and 38")
+	AssertParseError(t, "CREATE TABLE t (a DECIMAL(4, 5));", "DECIMAL scale must not exceed its precision")
+	AssertParseError(t, "CREATE TABLE t (a DECIMAL(4, 'x'));", "Expected DECIMAL scale")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER(4));", "Expected ',' or ')")
+}
+
+func TestParseInsertTypedLiterals(t *testing.T) {
+	parser := NewParser("INSERT INTO t VALUES (X'00ff', DATE '2024-02-29', TIME '12:30', TIMESTAMP '2024-02-29 12:30:00');")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Equal(t, []Expression{
+		&LiteralExpression{NODE_BLOB_VALUE, 22, lex.MakeToken(lex.TOKEN_LITERAL_BLOB, "00ff", 22)},
+		&LiteralExpression{NODE_DATE_VALUE, 31, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "2024-02-29", 36)},
+		&LiteralExpression{NODE_TIME_VALUE, 50, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "12:30", 55)},
+		&LiteralExpression{NODE_TIMESTAMP_VALUE, 64, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "2024-02-29 12:30:00", 74)},
+	}, content.rows[0])
+}
+
+func TestParseInsertLiteralErrors(t *testing.T) {
+	AssertParseError(t, "INSERT INTO t VALUES (DATE '2023-02-29');", "Invalid DATE value: '2023-02-29'")
+	AssertParseError(t, "INSERT INTO t VALUES (TIME 'noon');", "Invalid TIME value: 'noon'")
+	AssertParseError(t, "INSERT INTO t VALUES (TIMESTAMP 1);", "Expected text literal")
+	AssertParseError(t, "INSERT INTO t VALUES (9223372036854775808);", "INTEGER value out of range: 9223372036854775808")
+	AssertParseError(t, "INSERT INTO t VALUES (1e999);", "REAL value out of range: 1e999")
+}
+
+func TestParseInsertIntegerBounds(t *testing.T) {
+	parser := NewParser("INSERT INTO t VALUES (-9223372036854775808);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Equal(t, []Expression{
+		&UnaryExpression{
+			NODE_UNARY_EXPRESSION, 22, lex.MakeToken(lex.TOKEN_MINUS, "", 22),
+			&LiteralExpression{NODE_INTEGER_VALUE, 23, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "9223372036854775808", 23)},
+		},
+	}, content.rows[0])
+
+	AssertParseError(t, "INSERT INTO t VALUES (+9223372036854775808);", "INTEGER value out of range: 9223372036854775808")
+}
+
 func TestParseSelectSingleColumn(t *testing.T) {
 	parser := NewParser("SELECT c_1 FROM t;")
 	result := parser.Parse()
@@ -109,10 +233,17 @@ func TestParseSelectSingleColumn(t *testing.T) {
 	assert.Equal(t, &SelectStatement{
 		NODE_SELECT_STATEMENT,
 		0,
-		[]lex.Token{
-			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_1", 7),
+		false,
+		[]ResultColumn{
+			{&ColumnExpression{NODE_COLUMN_EXPRESSION, 7, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_1", 7)}, lex.Token{}},
 		},
-		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 16),
+		&TableName{NODE_TABLE_NAME, 16, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 16), lex.Token{}},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	}, content)
 }
 
@@ -125,12 +256,19 @@ func TestParseSelectMultiColumn(t *testing.T) {
 	assert.Equal(t, &SelectStatement{
 		NODE_SELECT_STATEMENT,
 		0,
-		[]lex.Token{
-			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_1", 7),
-			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_2", 12),
-			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_3", 16),
+		false,
+		[]ResultColumn{
+			{&ColumnExpression{NODE_COLUMN_EXPRESSION, 7, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_1", 7)}, lex.Token{}},
+			{&ColumnExpression{NODE_COLUMN_EXPRESSION, 12, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_2", 12)}, lex.Token{}},
+			{&ColumnExpression{NODE_COLUMN_EXPRESSION, 16, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_3", 16)}, lex.Token{}},
 		},
-		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 25),
+		&TableName{NODE_TABLE_NAME, 25, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 25), lex.Token{}},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	}, content)
 }
 
@@ -143,10 +281,17 @@ func TestParseSelectStar(t *testing.T) {
 	assert.Equal(t, &SelectStatement{
 		NODE_SELECT_STATEMENT,
 		0,
-		[]lex.Token{
-			lex.MakeToken(lex.TOKEN_ASTERISK, "", 7),
+		false,
+		[]ResultColumn{
+			{&StarExpression{NODE_STAR_EXPRESSION, 7, lex.Token{}}, lex.Token{}},
 		},
-		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 14),
+		&TableName{NODE_TABLE_NAME, 14, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 14), lex.Token{}},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	}, content)
 }
 
@@ -161,7 +306,9 @@ func TestMultipleStatements(t *testing.T) {
 		0,
 		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 13),
 		[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_1", 16)},
-		[]lex.Token{lex.MakeToken(lex.TOKEN_KEYWORD_TEXT, "", 20)},
+		[]DataType{DataType{lex.MakeToken(lex.TOKEN_KEYWORD_TEXT, "", 20), nil}},
+		[][]Constraint{nil},
+		nil,
 	}, create_stmt)
 
 	insert_stmt := result[1].Content.(*InsertStatement)
@@ -170,18 +317,1190 @@ func TestMultipleStatements(t *testing.T) {
 		27,
 		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 39),
 		nil,
-		[]lex.Token{
-			lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "James", 49),
-		},
+		[][]Expression{{
+			&LiteralExpression{NODE_TEXT_VALUE, 49, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "James", 49)},
+		}},
+		nil,
+		nil,
+		nil,
 	}, insert_stmt)
 
 	select_stmt := result[2].Content.(*SelectStatement)
 	assert.Equal(t, &SelectStatement{
 		NODE_SELECT_STATEMENT,
 		59,
-		[]lex.Token{
-			lex.MakeToken(lex.TOKEN_ASTERISK, "", 66),
+		false,
+		[]ResultColumn{
+			{&StarExpression{NODE_STAR_EXPRESSION, 66, lex.Token{}}, lex.Token{}},
 		},
-		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 73),
+		&TableName{NODE_TABLE_NAME, 73, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 73), lex.Token{}},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	}, select_stmt)
 }
+
+func TestParseInsertNull(t *testing.T) {
+	parser := NewParser("INSERT INTO t VALUES (NULL, (1));")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Equal(t, []Expression{
+		&LiteralExpression{NODE_NULL_VALUE, 22, lex.MakeToken(lex.TOKEN_KEYWORD_NULL, "", 22)},
+		&LiteralExpression{NODE_INTEGER_VALUE, 29, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 29)},
+	}, content.rows[0])
+}
+
+func TestParseSelectWhere(t *testing.T) {
+	parser := NewParser("SELECT c_1 FROM t WHERE c_1 IS NOT NULL AND NOT c_2 = 1 OR c_3 IS NULL;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, &BinaryExpression{
+		NODE_BINARY_EXPRESSION, 24, lex.MakeToken(lex.TOKEN_KEYWORD_OR, "", 56),
+		&BinaryExpression{
+			NODE_BINARY_EXPRESSION, 24, lex.MakeToken(lex.TOKEN_KEYWORD_AND, "", 40),
+			&IsNullExpression{
+				NODE_IS_NULL_EXPRESSION, 24,
+				&ColumnExpression{NODE_COLUMN_EXPRESSION, 24, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_1", 24)},
+				true,
+			},
+			&UnaryExpression{
+				NODE_UNARY_EXPRESSION, 44, lex.MakeToken(lex.TOKEN_KEYWORD_NOT, "", 44),
+				&BinaryExpression{
+					NODE_BINARY_EXPRESSION, 48, lex.MakeToken(lex.TOKEN_EQUAL, "", 52),
+					&ColumnExpression{NODE_COLUMN_EXPRESSION, 48, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_2", 48)},
+					&LiteralExpression{NODE_INTEGER_VALUE, 54, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 54)},
+				},
+			},
+		},
+		&IsNullExpression{
+			NODE_IS_NULL_EXPRESSION, 59,
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 59, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c_3", 59)},
+			false,
+		},
+	}, content.where)
+}
+
+func TestParseWhereErrors(t *testing.T) {
+	AssertParseError(t, "SELECT * FROM t WHERE c_1 IS 1;", "Expected NULL")
+	AssertParseError(t, "SELECT * FROM t WHERE c_1 < c_2 < c_3;", "Comparison operators cannot be chained")
+	AssertParseError(t, "SELECT * FROM t WHERE (c_1 = 1;", "Expected ')'")
+	AssertParseError(t, "SELECT * FROM t WHERE;", "Expected expression")
+}
+
+func TestParseCreateTableConstraints(t *testing.T) {
+	parser := NewParser("CREATE TABLE t (id INTEGER CONSTRAINT pk PRIMARY KEY, name TEXT NOT NULL UNIQUE, qty INTEGER NULL DEFAULT -1 CHECK (qty >= 0), UNIQUE (name, qty), CONSTRAINT positive CHECK (id > 0));")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*CreateTableStatement)
+	assert.Equal(t, [][]Constraint{
+		{
+			{CONSTRAINT_PRIMARY_KEY, 27, lex.MakeToken(lex.TOKEN_IDENTIFIER, "pk", 38), nil, nil, nil},
+		},
+		{
+			{CONSTRAINT_NOT_NULL, 64, lex.Token{}, nil, nil, nil},
+			{CONSTRAINT_UNIQUE, 73, lex.Token{}, nil, nil, nil},
+		},
+		{
+			{CONSTRAINT_DEFAULT, 98, lex.Token{}, nil, &UnaryExpression{
+				NODE_UNARY_EXPRESSION, 106, lex.MakeToken(lex.TOKEN_MINUS, "", 106),
+				&LiteralExpression{NODE_INTEGER_VALUE, 107, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 107)},
+			}, nil},
+			{CONSTRAINT_CHECK, 109, lex.Token{}, nil, &BinaryExpression{
+				NODE_BINARY_EXPRESSION, 116, lex.MakeToken(lex.TOKEN_GREATER_EQUAL, "", 120),
+				&ColumnExpression{NODE_COLUMN_EXPRESSION, 116, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "qty", 116)},
+				&LiteralExpression{NODE_INTEGER_VALUE, 123, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "0", 123)},
+			}, nil},
+		},
+	}, content.column_constraints)
+
+	assert.Equal(t, []Constraint{
+		{CONSTRAINT_UNIQUE, 127, lex.Token{}, []lex.Token{
+			lex.MakeToken(lex.TOKEN_IDENTIFIER, "name", 135), lex.MakeToken(lex.TOKEN_IDENTIFIER, "qty", 141),
+		}, nil, nil},
+		{CONSTRAINT_CHECK, 147, lex.MakeToken(lex.TOKEN_IDENTIFIER, "positive", 158), nil, &BinaryExpression{
+			NODE_BINARY_EXPRESSION, 174, lex.MakeToken(lex.TOKEN_GREATER, "", 177),
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 174, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 174)},
+			&LiteralExpression{NODE_INTEGER_VALUE, 179, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "0", 179)},
+		}, nil},
+	}, content.constraints)
+}
+
+func TestParseCreateTableConstraintErrors(t *testing.T) {
+	AssertParseError(t, "CREATE TABLE t (a INTEGER PRIMARY);", "Expected KEY")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER CONSTRAINT c);", "Expected constraint")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER CHECK a > 0);", "Expected '('")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER, CONSTRAINT c a);", "Expected PRIMARY KEY, UNIQUE, CHECK or FOREIGN KEY")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER, UNIQUE (b));", `Column "b" named in key does not exist`)
+	AssertParseError(t, "CREATE TABLE t (a INTEGER, a TEXT);", `Column "a" specified more than once`)
+	AssertParseError(t, "CREATE TABLE t (a INTEGER, b TEXT, PRIMARY KEY (a, b, a));", `Column "a" appears twice in primary key constraint`)
+	AssertParseError(t, "CREATE TABLE t (a INTEGER, UNIQUE (a, a));", `Column "a" appears twice in unique constraint`)
+	AssertParseError(t, "CREATE TABLE t (a INTEGER PRIMARY KEY, b TEXT, PRIMARY KEY (b));", `Multiple primary keys for table "t" are not allowed`)
+}
+
+func TestParseCreateTableForeignKeys(t *testing.T) {
+	parser := NewParser("CREATE TABLE c (id INTEGER, p INTEGER REFERENCES parent ON DELETE CASCADE NOT NULL, q INTEGER, r INTEGER, FOREIGN KEY (q, r) REFERENCES c (id, p) ON UPDATE SET NULL ON DELETE NO ACTION INITIALLY DEFERRED);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*CreateTableStatement)
+	assert.Equal(t, []Constraint{
+		{CONSTRAINT_FOREIGN_KEY, 38, lex.Token{}, nil, nil, &Reference{
+			lex.MakeToken(lex.TOKEN_IDENTIFIER, "parent", 49), nil, ACTION_CASCADE, ACTION_NO_ACTION, false, false,
+		}},
+		{CONSTRAINT_NOT_NULL, 74, lex.Token{}, nil, nil, nil},
+	}, content.column_constraints[1])
+
+	assert.Equal(t, []Constraint{
+		{CONSTRAINT_FOREIGN_KEY, 106, lex.Token{}, []lex.Token{
+			lex.MakeToken(lex.TOKEN_IDENTIFIER, "q", 119), lex.MakeToken(lex.TOKEN_IDENTIFIER, "r", 122),
+		}, nil, &Reference{
+			lex.MakeToken(lex.TOKEN_IDENTIFIER, "c", 136),
+			[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 139), lex.MakeToken(lex.TOKEN_IDENTIFIER, "p", 143)},
+			ACTION_NO_ACTION, ACTION_SET_NULL, true, true,
+		}},
+	}, content.constraints)
+}
+
+func TestParseCreateTableForeignKeyErrors(t *testing.T) {
+	AssertParseError(t, "CREATE TABLE t (a INTEGER REFERENCES p (x, y));", "Number of referencing and referenced columns for foreign key disagree")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER, FOREIGN KEY (a) REFERENCES p (x, y));", "Number of referencing and referenced columns for foreign key disagree")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER, FOREIGN KEY (a) p);", "Expected REFERENCES")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER REFERENCES p ON INSERT CASCADE);", "Expected DELETE or UPDATE")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER REFERENCES p ON DELETE SET);", "Expected NULL or DEFAULT")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER REFERENCES p ON DELETE NOTHING);", "Expected CASCADE, RESTRICT, NO ACTION, SET NULL or SET DEFAULT")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER REFERENCES p NOT DEFERRABLE INITIALLY DEFERRED);", "Constraint declared INITIALLY DEFERRED must be DEFERRABLE")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER REFERENCES t (b));", `Column "b" referenced in foreign key constraint does not exist`)
+}
+
+func TestParseCreateTableIdentity(t *testing.T) {
+	parser := NewParser("CREATE TABLE t (id INTEGER PRIMARY KEY AUTOINCREMENT, n INTEGER GENERATED BY DEFAULT AS IDENTITY, m INTEGER GENERATED ALWAYS AS IDENTITY);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*CreateTableStatement)
+	assert.Equal(t, [][]Constraint{
+		{{CONSTRAINT_PRIMARY_KEY, 27, lex.Token{}, nil, nil, nil}, {CONSTRAINT_AUTOINCREMENT, 39, lex.Token{}, nil, nil, nil}},
+		{{CONSTRAINT_IDENTITY_BY_DEFAULT, 64, lex.Token{}, nil, nil, nil}},
+		{{CONSTRAINT_IDENTITY_ALWAYS, 108, lex.Token{}, nil, nil, nil}},
+	}, content.column_constraints)
+
+	parser = NewParser("CREATE TABLE t (id INTEGER AUTOINCREMENT NOT NULL PRIMARY KEY);")
+	result = parser.Parse()
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, [][]Constraint{
+		{{CONSTRAINT_AUTOINCREMENT, 27, lex.Token{}, nil, nil, nil}, {CONSTRAINT_NOT_NULL, 41, lex.Token{}, nil, nil, nil}, {CONSTRAINT_PRIMARY_KEY, 50, lex.Token{}, nil, nil, nil}},
+	}, result[0].Content.(*CreateTableStatement).column_constraints)
+}
+
+func TestParseCreateTableIdentityErrors(t *testing.T) {
+	AssertParseError(t, "CREATE TABLE t (id INTEGER AUTOINCREMENT);", "AUTOINCREMENT is only allowed on an INTEGER PRIMARY KEY")
+	AssertParseError(t, "CREATE TABLE t (id TEXT PRIMARY KEY AUTOINCREMENT);", "AUTOINCREMENT is only allowed on an INTEGER PRIMARY KEY")
+	AssertParseError(t, "CREATE TABLE t (id REAL GENERATED ALWAYS AS IDENTITY);", `Identity column "id" must be of type INTEGER`)
+	AssertParseError(t, "CREATE TABLE t (id INTEGER GENERATED AS IDENTITY);", "Expected ALWAYS or BY DEFAULT")
+	AssertParseError(t, "CREATE TABLE t (id INTEGER GENERATED ALWAYS IDENTITY);", "Expected AS")
+	AssertParseError(t, "CREATE TABLE t (id INTEGER DEFAULT 1 GENERATED ALWAYS AS IDENTITY);", `Both default and identity specified for column "id"`)
+	AssertParseError(t, "CREATE TABLE t (id INTEGER PRIMARY KEY AUTOINCREMENT GENERATED ALWAYS AS IDENTITY);", `Multiple identity specifications for column "id"`)
+}
+
+func TestParseInsertReturning(t *testing.T) {
+	parser := NewParser("INSERT INTO t VALUES (1) RETURNING id;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Equal(t, []ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 35, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 35)}, lex.Token{}}}, content.returning)
+}
+
+func TestParseUpdate(t *testing.T) {
+	parser := NewParser("UPDATE t SET a = 1, b = NULL WHERE id = 2 RETURNING id, a;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*UpdateStatement)
+	assert.Equal(t, &UpdateStatement{
+		NODE_UPDATE_STATEMENT,
+		0,
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 7),
+		[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 13), lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 20)},
+		[]Expression{
+			&LiteralExpression{NODE_INTEGER_VALUE, 17, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 17)},
+			&LiteralExpression{NODE_NULL_VALUE, 24, lex.MakeToken(lex.TOKEN_KEYWORD_NULL, "", 24)},
+		},
+		&BinaryExpression{
+			NODE_BINARY_EXPRESSION, 35, lex.MakeToken(lex.TOKEN_EQUAL, "", 38),
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 35, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 35)},
+			&LiteralExpression{NODE_INTEGER_VALUE, 40, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "2", 40)},
+		},
+		[]ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 52, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 52)}, lex.Token{}}, {&ColumnExpression{NODE_COLUMN_EXPRESSION, 56, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 56)}, lex.Token{}}},
+	}, content)
+
+	AssertParseError(t, "UPDATE t a = 1;", "Expected SET")
+	AssertParseError(t, "UPDATE t SET a 1;", "Expected '='")
+}
+
+func TestParseDelete(t *testing.T) {
+	parser := NewParser("DELETE FROM t WHERE a IS NULL RETURNING *;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*DeleteStatement)
+	assert.Equal(t, &DeleteStatement{
+		NODE_DELETE_STATEMENT,
+		0,
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 12),
+		&IsNullExpression{
+			NODE_IS_NULL_EXPRESSION, 20,
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 20, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 20)},
+			false,
+		},
+		[]ResultColumn{{&StarExpression{NODE_STAR_EXPRESSION, 40, lex.Token{}}, lex.Token{}}},
+	}, content)
+
+	AssertParseError(t, "DELETE t;", "Expected FROM")
+}
+
+func TestParseCreateIndex(t *testing.T) {
+	parser := NewParser("CREATE UNIQUE INDEX t_ab ON t (a, b DESC); CREATE INDEX t_c ON t (c ASC);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, &CreateIndexStatement{
+		NODE_CREATE_INDEX_STATEMENT,
+		0,
+		true,
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t_ab", 20),
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 28),
+		[]IndexedColumn{
+			{lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 31), false},
+			{lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 34), true},
+		},
+	}, result[0].Content.(*CreateIndexStatement))
+	assert.Equal(t, &CreateIndexStatement{
+		NODE_CREATE_INDEX_STATEMENT,
+		43,
+		false,
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t_c", 56),
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 63),
+		[]IndexedColumn{{lex.MakeToken(lex.TOKEN_IDENTIFIER, "c", 66), false}},
+	}, result[1].Content.(*CreateIndexStatement))
+
+	AssertParseError(t, "CREATE UNIQUE t_a ON t (a);", "Expected INDEX")
+	AssertParseError(t, "CREATE INDEX ON t (a);", "Expected identifier")
+	AssertParseError(t, "CREATE INDEX t_a t (a);", "Expected ON")
+	AssertParseError(t, "CREATE INDEX t_a ON t a;", "Expected '('")
+	AssertParseError(t, "CREATE INDEX t_a ON t ();", "Expected identifier")
+	AssertParseError(t, "CREATE INDEX t_a ON t (a b);", "Expected ',' or ')")
+	AssertParseError(t, "CREATE INDEX t_a ON t (a, A);", "Column \"a\" specified more than once")
+}
+
+func TestParseDropIndex(t *testing.T) {
+	parser := NewParser("DROP INDEX t_a;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, &DropIndexStatement{
+		NODE_DROP_INDEX_STATEMENT,
+		0,
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "t_a", 11),
+	}, result[0].Content.(*DropIndexStatement))
+
+	AssertParseError(t, "DROP INDEX;", "Expected identifier")
+	AssertParseError(t, "DROP t_a;", "Unhandled DROP statement")
+}
+
+func TestParseInsertMultiRow(t *testing.T) {
+	parser := NewParser("INSERT INTO t (a, b) VALUES (1, 2), (3, NULL);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Equal(t, [][]Expression{
+		{
+			&LiteralExpression{NODE_INTEGER_VALUE, 29, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 29)},
+			&LiteralExpression{NODE_INTEGER_VALUE, 32, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "2", 32)},
+		},
+		{
+			&LiteralExpression{NODE_INTEGER_VALUE, 37, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "3", 37)},
+			&LiteralExpression{NODE_NULL_VALUE, 40, lex.MakeToken(lex.TOKEN_KEYWORD_NULL, "", 40)},
+		},
+	}, content.rows)
+}
+
+func TestParseInsertSelect(t *testing.T) {
+	parser := NewParser("INSERT INTO t (a) SELECT b FROM u WHERE b IS NOT NULL;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Nil(t, content.rows)
+	assert.Equal(t, &SelectStatement{
+		NODE_SELECT_STATEMENT,
+		18,
+		false,
+		[]ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 25, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 25)}, lex.Token{}}},
+		&TableName{NODE_TABLE_NAME, 32, lex.MakeToken(lex.TOKEN_IDENTIFIER, "u", 32), lex.Token{}},
+		&IsNullExpression{
+			NODE_IS_NULL_EXPRESSION, 40,
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 40, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 40)},
+			true,
+		},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	}, content.query)
+}
+
+func TestParseInsertDefaultValues(t *testing.T) {
+	parser := NewParser("INSERT INTO t DEFAULT VALUES RETURNING *;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Nil(t, content.rows)
+	assert.Nil(t, content.query)
+	assert.Equal(t, []ResultColumn{{&StarExpression{NODE_STAR_EXPRESSION, 39, lex.Token{}}, lex.Token{}}}, content.returning)
+}
+
+func TestParseInsertRowErrors(t *testing.T) {
+	AssertParseError(t, "INSERT INTO t VALUES (1, 2), (3);", "VALUES lists must all be the same length")
+	AssertParseError(t, "INSERT INTO t (a) VALUES (1, 2);", "INSERT has more expressions than target columns")
+	AssertParseError(t, "INSERT INTO t (a, b) VALUES (1);", "INSERT has more target columns than expressions")
+	AssertParseError(t, "INSERT INTO t (a, b) SELECT x FROM u;", "INSERT has more target columns than expressions")
+	AssertParseError(t, "INSERT INTO t (a) SELECT x, y FROM u;", "INSERT has more expressions than target columns")
+	AssertParseError(t, "INSERT INTO t (a, b) WITH w AS (SELECT 1 AS x FROM u) SELECT x FROM w;", "INSERT has more target columns than expressions")
+	AssertParseError(t, "INSERT INTO t (a) DEFAULT VALUES;", "DEFAULT VALUES cannot be used with a column list")
+	AssertParseError(t, "INSERT INTO t DEFAULT;", "Expected VALUES")
+	AssertParseError(t, "INSERT INTO t (1);", "Expected identifier")
+	AssertParseError(t, "INSERT INTO t;", "Expected VALUES, SELECT or DEFAULT VALUES")
+}
+
+func TestParseInsertOnConflict(t *testing.T) {
+	parser := NewParser("INSERT INTO t (id, n) VALUES (1, 2) ON CONFLICT (id) DO UPDATE SET n = excluded.n WHERE t.n < excluded.n RETURNING n;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Equal(t, &OnConflict{
+		[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 49)},
+		true,
+		[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "n", 67)},
+		[]Expression{
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 71, lex.MakeToken(lex.TOKEN_IDENTIFIER, "excluded", 71), lex.MakeToken(lex.TOKEN_IDENTIFIER, "n", 80)},
+		},
+		&BinaryExpression{
+			NODE_BINARY_EXPRESSION, 88, lex.MakeToken(lex.TOKEN_LESS, "", 92),
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 88, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 88), lex.MakeToken(lex.TOKEN_IDENTIFIER, "n", 90)},
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 94, lex.MakeToken(lex.TOKEN_IDENTIFIER, "excluded", 94), lex.MakeToken(lex.TOKEN_IDENTIFIER, "n", 103)},
+		},
+	}, content.on_conflict)
+	assert.Equal(t, []ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 115, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "n", 115)}, lex.Token{}}}, content.returning)
+}
+
+func TestParseInsertOnConflictDoNothing(t *testing.T) {
+	parser := NewParser("INSERT INTO t VALUES (1) ON CONFLICT DO NOTHING;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*InsertStatement)
+	assert.Equal(t, &OnConflict{}, content.on_conflict)
+
+	AssertParseError(t, "INSERT INTO t VALUES (1) ON CONFLICT DO UPDATE SET a = 1;", "ON CONFLICT DO UPDATE requires a conflict target")
+	AssertParseError(t, "INSERT INTO t VALUES (1) ON CONFLICT (a) DO SKIP;", "Expected NOTHING or UPDATE")
+	AssertParseError(t, "INSERT INTO t VALUES (1) ON CONFLICT (a) UPDATE;", "Expected DO")
+	AssertParseError(t, "INSERT INTO t VALUES (1) ON DUPLICATE;", "Expected CONFLICT")
+	AssertParseError(t, "INSERT INTO t VALUES (excluded.);", "Expected column name")
+}
+
+func TestParseSelectOrderByLimit(t *testing.T) {
+	parser := NewParser("SELECT a FROM t ORDER BY a DESC, b NULLS FIRST, c ASC NULLS LAST, d DESC NULLS LAST LIMIT $1 OFFSET $3;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, []OrderingTerm{
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 25, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 25)}, true, true},
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 33, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 33)}, false, true},
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 48, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c", 48)}, false, false},
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 66, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "d", 66)}, true, false},
+	}, content.order_by)
+	assert.Equal(t, &ParameterExpression{NODE_PARAMETER_EXPRESSION, 90, 1}, content.limit)
+	assert.Equal(t, &ParameterExpression{NODE_PARAMETER_EXPRESSION, 100, 3}, content.offset)
+}
+
+func TestParseSelectOrderByErrors(t *testing.T) {
+	AssertParseError(t, "SELECT a FROM t ORDER a;", "Expected BY")
+	AssertParseError(t, "SELECT a FROM t ORDER BY a NULLS;", "Expected FIRST or LAST")
+	AssertParseError(t, "SELECT a FROM t LIMIT $0;", "There is no parameter $0")
+	AssertParseError(t, "SELECT a FROM t WHERE a = ? AND b = $1;", "Cannot mix ? and $n parameters in one statement")
+	AssertParseError(t, "SELECT a FROM t WHERE a = $1 AND b = ?;", "Cannot mix ? and $n parameters in one statement")
+}
+
+func TestParseSelectAggregates(t *testing.T) {
+	parser := NewParser("SELECT DISTINCT a, count(*), COUNT(DISTINCT b) FROM t GROUP BY a HAVING max(c) > 1;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.True(t, content.distinct)
+	assert.Equal(t, []ResultColumn{
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 16, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 16)}, lex.Token{}},
+		{&FunctionExpression{NODE_FUNCTION_EXPRESSION, 19, lex.MakeToken(lex.TOKEN_IDENTIFIER, "count", 19), nil, false, true}, lex.Token{}},
+		{&FunctionExpression{NODE_FUNCTION_EXPRESSION, 29, lex.MakeToken(lex.TOKEN_IDENTIFIER, "count", 29), []Expression{
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 44, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 44)},
+		}, true, false}, lex.Token{}},
+	}, content.columns)
+	assert.Equal(t, []Expression{
+		&ColumnExpression{NODE_COLUMN_EXPRESSION, 63, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 63)},
+	}, content.group_by)
+	assert.Equal(t, &BinaryExpression{
+		NODE_BINARY_EXPRESSION, 72, lex.MakeToken(lex.TOKEN_GREATER, "", 79),
+		&FunctionExpression{NODE_FUNCTION_EXPRESSION, 72, lex.MakeToken(lex.TOKEN_IDENTIFIER, "max", 72), []Expression{
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 76, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c", 76)},
+		}, false, false},
+		&LiteralExpression{NODE_INTEGER_VALUE, 81, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 81)},
+	}, content.having)
+}
+
+func TestParseSelectStars(t *testing.T) {
+	parser := NewParser("SELECT t.*, * FROM t;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, []ResultColumn{
+		{&StarExpression{NODE_STAR_EXPRESSION, 7, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 7)}, lex.Token{}},
+		{&StarExpression{NODE_STAR_EXPRESSION, 12, lex.Token{}}, lex.Token{}},
+	}, content.columns)
+}
+
+func TestParseSelectGrouping(t *testing.T) {
+	parser := NewParser("SELECT a, b IS NULL, sum(c) FROM t GROUP BY a, b IS NULL HAVING count(*) > 1 ORDER BY a, min(c);")
+	assert.Len(t, parser.Parse(), 1)
+
+	parser = NewParser("SELECT a.x, count(*) FROM t AS a GROUP BY x; SELECT x FROM t GROUP BY t.x; SELECT u.x + 1 FROM t JOIN u ON t.id = u.id GROUP BY x + 1;")
+	assert.Len(t, parser.Parse(), 3)
+
+	AssertParseError(t, "SELECT a.x FROM t AS a GROUP BY b.x;", `Column "x" must appear in the GROUP BY clause or be used in an aggregate function`)
+	AssertParseError(t, "SELECT x FROM t AS a GROUP BY t.x;", `Column "x" must appear in the GROUP BY clause or be used in an aggregate function`)
+	AssertParseError(t, "SELECT a, count(*) FROM t;", `Column "a" must appear in the GROUP BY clause or be used in an aggregate function`)
+	AssertParseError(t, "SELECT a FROM t GROUP BY b;", `Column "a" must appear in the GROUP BY clause or be used in an aggregate function`)
+	AssertParseError(t, "SELECT b IS NULL FROM t GROUP BY b IS NOT NULL;", `Column "b" must appear in the GROUP BY clause or be used in an aggregate function`)
+	AssertParseError(t, "SELECT a FROM t GROUP BY a ORDER BY b;", `Column "b" must appear in the GROUP BY clause or be used in an aggregate function`)
+	AssertParseError(t, "SELECT a FROM t HAVING a > 1;", `Column "a" must appear in the GROUP BY clause or be used in an aggregate function`)
+	AssertParseError(t, "SELECT a FROM t WHERE count(*) > 1;", "Aggregate functions are not allowed in WHERE")
+	AssertParseError(t, "SELECT a FROM t GROUP BY count(a);", "Aggregate functions are not allowed in GROUP BY")
+	AssertParseError(t, "SELECT sum(max(a)) FROM t;", "Aggregate function calls cannot be nested")
+	AssertParseError(t, "SELECT sum(*) FROM t;", "sum(*) is not allowed")
+	AssertParseError(t, "SELECT lower(DISTINCT a) FROM t;", "DISTINCT specified, but lower is not an aggregate function")
+	AssertParseError(t, "SELECT sum(a, b) FROM t;", "Function sum takes exactly one argument")
+	AssertParseError(t, "SELECT count() FROM t;", "Function count takes exactly one argument")
+	AssertParseError(t, "SELECT count(a FROM t;", "Expected ',' or ')")
+	AssertParseError(t, "SELECT *, count(*) FROM t GROUP BY a;", "Column * must appear in the GROUP BY clause or be used in an aggregate function")
+	AssertParseError(t, "SELECT t.*, count(*) FROM t;", `Column "t".* must appear in the GROUP BY clause or be used in an aggregate function`)
+}
+
+func TestParseAggregatesOutsideSelect(t *testing.T) {
+	AssertParseError(t, "UPDATE t SET a = sum(b);", "Aggregate functions are not allowed in UPDATE")
+	AssertParseError(t, "UPDATE t SET a = 1 WHERE max(b) > 1;", "Aggregate functions are not allowed in WHERE")
+	AssertParseError(t, "DELETE FROM t WHERE count(a) > 1;", "Aggregate functions are not allowed in WHERE")
+	AssertParseError(t, "DELETE FROM t RETURNING count(a);", "Aggregate functions are not allowed in RETURNING")
+	AssertParseError(t, "INSERT INTO t VALUES (max(1));", "Aggregate functions are not allowed in VALUES")
+	AssertParseError(t, "INSERT INTO t (a) VALUES (1) ON CONFLICT (a) DO UPDATE SET a = min(a);", "Aggregate functions are not allowed in UPDATE")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER CHECK (sum(a) > 0));", "Aggregate functions are not allowed in check constraints")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER, CHECK (sum(a) > 0));", "Aggregate functions are not allowed in check constraints")
+	AssertParseError(t, "CREATE TABLE t (a INTEGER DEFAULT avg(1));", "Aggregate functions are not allowed in DEFAULT expressions")
+	AssertParseError(t, "UPDATE t SET a = row_number() OVER ();", "Window functions are not allowed in UPDATE")
+}
+
+func TestParseSelectJoins(t *testing.T) {
+	parser := NewParser("SELECT a.x, b.y FROM t AS a LEFT OUTER JOIN u b ON a.id = b.id CROSS JOIN v, w FULL JOIN z USING (id, k);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, &JoinClause{
+		NODE_JOIN_CLAUSE, 21, JOIN_CROSS,
+		&JoinClause{
+			NODE_JOIN_CLAUSE, 21, JOIN_CROSS,
+			&JoinClause{
+				NODE_JOIN_CLAUSE, 21, JOIN_LEFT,
+				&TableName{NODE_TABLE_NAME, 21, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 21), lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 26)},
+				&TableName{NODE_TABLE_NAME, 44, lex.MakeToken(lex.TOKEN_IDENTIFIER, "u", 44), lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 46)},
+				&BinaryExpression{
+					NODE_BINARY_EXPRESSION, 51, lex.MakeToken(lex.TOKEN_EQUAL, "", 56),
+					&ColumnExpression{NODE_COLUMN_EXPRESSION, 51, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 51), lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 53)},
+					&ColumnExpression{NODE_COLUMN_EXPRESSION, 58, lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 58), lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 60)},
+				},
+				nil,
+			},
+			&TableName{NODE_TABLE_NAME, 74, lex.MakeToken(lex.TOKEN_IDENTIFIER, "v", 74), lex.Token{}},
+			nil,
+			nil,
+		},
+		&JoinClause{
+			NODE_JOIN_CLAUSE, 77, JOIN_FULL,
+			&TableName{NODE_TABLE_NAME, 77, lex.MakeToken(lex.TOKEN_IDENTIFIER, "w", 77), lex.Token{}},
+			&TableName{NODE_TABLE_NAME, 89, lex.MakeToken(lex.TOKEN_IDENTIFIER, "z", 89), lex.Token{}},
+			nil,
+			[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "id", 98), lex.MakeToken(lex.TOKEN_IDENTIFIER, "k", 102)},
+		},
+		nil,
+		nil,
+	}, content.from)
+}
+
+func TestParseSelectJoinTypes(t *testing.T) {
+	joins := map[string]JoinType{"JOIN": JOIN_INNER, "INNER JOIN": JOIN_INNER, "LEFT JOIN": JOIN_LEFT, "RIGHT OUTER JOIN": JOIN_RIGHT, "FULL OUTER JOIN": JOIN_FULL}
+	for join, join_type := range joins {
+		parser := NewParser("SELECT * FROM t " + join + " u ON t.a = u.a;")
+		result := parser.Parse()
+
+		assert.Len(t, result, 1, join)
+		assert.Equal(t, join_type, result[0].Content.(*SelectStatement).from.(*JoinClause).join_type, join)
+	}
+
+	AssertParseError(t, "SELECT * FROM t JOIN u;", "Expected ON or USING")
+	AssertParseError(t, "SELECT * FROM t LEFT u ON t.a = u.a;", "Expected JOIN")
+	AssertParseError(t, "SELECT * FROM t AS;", "Expected alias")
+	AssertParseError(t, "SELECT * FROM t, t;", `Table name "t" specified more than once`)
+	AssertParseError(t, "SELECT * FROM t a JOIN u a USING (id);", `Table name "a" specified more than once`)
+	AssertParseError(t, "SELECT * FROM t JOIN u ON count(*) > 1;", "Aggregate functions are not allowed in JOIN conditions")
+}
+
+func TestParseSubqueries(t *testing.T) {
+	parser := NewParser("SELECT s.k FROM (SELECT k FROM u) AS s WHERE s.k NOT IN (SELECT k FROM v) AND EXISTS (SELECT * FROM w);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, &SubqueryTable{
+		NODE_SUBQUERY_TABLE, 16,
+		&SelectStatement{
+			NODE_SELECT_STATEMENT,
+			17,
+			false,
+			[]ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 24, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "k", 24)}, lex.Token{}}},
+			&TableName{NODE_TABLE_NAME, 31, lex.MakeToken(lex.TOKEN_IDENTIFIER, "u", 31), lex.Token{}},
+			nil, nil, nil, nil, nil, nil,
+		},
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "s", 37),
+	}, content.from)
+
+	where := content.where.(*BinaryExpression)
+	assert.Equal(t, &InExpression{
+		NODE_IN_EXPRESSION, 45,
+		&ColumnExpression{NODE_COLUMN_EXPRESSION, 45, lex.MakeToken(lex.TOKEN_IDENTIFIER, "s", 45), lex.MakeToken(lex.TOKEN_IDENTIFIER, "k", 47)},
+		&SelectStatement{
+			NODE_SELECT_STATEMENT,
+			57,
+			false,
+			[]ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 64, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "k", 64)}, lex.Token{}}},
+			&TableName{NODE_TABLE_NAME, 71, lex.MakeToken(lex.TOKEN_IDENTIFIER, "v", 71), lex.Token{}},
+			nil, nil, nil, nil, nil, nil,
+		},
+		nil,
+		true,
+	}, where.left)
+	assert.Equal(t, &ExistsExpression{
+		NODE_EXISTS_EXPRESSION, 78,
+		&SelectStatement{
+			NODE_SELECT_STATEMENT,
+			86,
+			false,
+			[]ResultColumn{{&StarExpression{NODE_STAR_EXPRESSION, 93, lex.Token{}}, lex.Token{}}},
+			&TableName{NODE_TABLE_NAME, 100, lex.MakeToken(lex.TOKEN_IDENTIFIER, "w", 100), lex.Token{}},
+			nil, nil, nil, nil, nil, nil,
+		},
+	}, where.right)
+}
+
+func TestParseScalarSubquery(t *testing.T) {
+	parser := NewParser("SELECT a, (SELECT max(b) FROM u WHERE u.k = t.k) FROM t WHERE NOT EXISTS (SELECT 1 FROM v);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	subquery := content.columns[1].expression.(*SubqueryExpression)
+	assert.Equal(t, 10, subquery.Pos())
+	assert.Equal(t, 11, subquery.query.Pos())
+	assert.IsType(t, &ExistsExpression{}, content.where.(*UnaryExpression).operand)
+
+	parser = NewParser("SELECT count(*) FROM t WHERE a IN (SELECT max(b) FROM u GROUP BY c);")
+	assert.Len(t, parser.Parse(), 1)
+
+	AssertParseError(t, "SELECT * FROM (SELECT * FROM u);", "Subquery in FROM must have an alias")
+	AssertParseError(t, "SELECT * FROM (SELECT * FROM u) AS t, t;", `Table name "t" specified more than once`)
+	AssertParseError(t, "SELECT * FROM t WHERE a IN ();", "Expected expression")
+	AssertParseError(t, "SELECT * FROM t WHERE a IN SELECT;", "Expected '('")
+	AssertParseError(t, "SELECT * FROM t WHERE EXISTS (SELECT * FROM u;", "Expected ')'")
+}
+
+func TestParseCompoundSelect(t *testing.T) {
+	parser := NewParser("SELECT a FROM t UNION ALL SELECT b FROM u INTERSECT SELECT c FROM v ORDER BY a LIMIT 2;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*CompoundSelect)
+	assert.Equal(t, SET_UNION, content.operator)
+	assert.True(t, content.all)
+	assert.Equal(t, 0, content.Pos())
+	assert.Equal(t, []OrderingTerm{
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 77, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 77)}, false, false},
+	}, content.order_by)
+	assert.Equal(t, &LiteralExpression{NODE_INTEGER_VALUE, 85, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "2", 85)}, content.limit)
+
+	assert.Equal(t, &SelectStatement{
+		NODE_SELECT_STATEMENT,
+		0,
+		false,
+		[]ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 7, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 7)}, lex.Token{}}},
+		&TableName{NODE_TABLE_NAME, 14, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 14), lex.Token{}},
+		nil, nil, nil, nil, nil, nil,
+	}, content.left)
+
+	right := content.right.(*CompoundSelect)
+	assert.Equal(t, SET_INTERSECT, right.operator)
+	assert.False(t, right.all)
+	assert.Equal(t, 26, right.Pos())
+	assert.Equal(t, 52, right.right.Pos())
+	assert.Nil(t, right.order_by)
+
+	parser = NewParser("SELECT a FROM t EXCEPT DISTINCT SELECT b FROM u UNION SELECT c FROM v;")
+	content = parser.Parse()[0].Content.(*CompoundSelect)
+	assert.Equal(t, SET_UNION, content.operator)
+	assert.Equal(t, SET_EXCEPT, content.left.(*CompoundSelect).operator)
+}
+
+func TestParseCompoundSelectSubqueries(t *testing.T) {
+	parser := NewParser("INSERT INTO t SELECT a FROM u UNION SELECT b FROM v;")
+	content := parser.Parse()[0].Content.(*InsertStatement)
+	assert.IsType(t, &CompoundSelect{}, content.query)
+
+	parser = NewParser("SELECT * FROM t WHERE a IN (SELECT a FROM u EXCEPT SELECT a FROM v ORDER BY a);")
+	where := parser.Parse()[0].Content.(*SelectStatement).where.(*InExpression)
+	assert.Len(t, where.query.(*CompoundSelect).order_by, 1)
+
+	parser = NewParser("SELECT * FROM t UNION SELECT a, b FROM u;")
+	assert.Len(t, parser.Parse(), 1)
+}
+
+func TestParseCompoundSelectErrors(t *testing.T) {
+	AssertParseError(t, "SELECT a FROM t UNION SELECT a, b FROM u;", "Each UNION query must have the same number of columns")
+	AssertParseError(t, "SELECT a FROM t INTERSECT ALL SELECT a, b FROM u;", "Each INTERSECT query must have the same number of columns")
+	AssertParseError(t, "SELECT a, b FROM t EXCEPT SELECT a FROM u;", "Each EXCEPT query must have the same number of columns")
+	AssertParseError(t, "SELECT a FROM t UNION (SELECT a FROM u);", "Expected SELECT")
+	AssertParseError(t, "SELECT a FROM t UNION SELECT b FROM u GROUP BY c;", `Column "b" must appear in the GROUP BY clause or be used in an aggregate function`)
+}
+
+func TestParseWithQuery(t *testing.T) {
+	parser := NewParser("WITH a (k) AS (SELECT k FROM t), b AS (SELECT k FROM a) SELECT k FROM b;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*WithQuery)
+	assert.Equal(t, 0, content.Pos())
+	assert.False(t, content.recursive)
+	assert.Equal(t, []CommonTable{
+		{
+			lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 5),
+			[]lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "k", 8)},
+			&SelectStatement{
+				NODE_SELECT_STATEMENT,
+				15,
+				false,
+				[]ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 22, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "k", 22)}, lex.Token{}}},
+				&TableName{NODE_TABLE_NAME, 29, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 29), lex.Token{}},
+				nil, nil, nil, nil, nil, nil,
+			},
+		},
+		{
+			lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 33),
+			nil,
+			&SelectStatement{
+				NODE_SELECT_STATEMENT,
+				39,
+				false,
+				[]ResultColumn{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 46, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "k", 46)}, lex.Token{}}},
+				&TableName{NODE_TABLE_NAME, 53, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 53), lex.Token{}},
+				nil, nil, nil, nil, nil, nil,
+			},
+		},
+	}, content.tables)
+	assert.Equal(t, 56, content.query.Pos())
+
+	parser = NewParser("SELECT * FROM t WHERE k IN (WITH a AS (SELECT k FROM u) SELECT k FROM a);")
+	assert.IsType(t, &WithQuery{}, parser.Parse()[0].Content.(*SelectStatement).where.(*InExpression).query)
+
+	parser = NewParser("INSERT INTO t WITH a AS (SELECT k FROM u) SELECT k FROM a;")
+	assert.IsType(t, &WithQuery{}, parser.Parse()[0].Content.(*InsertStatement).query)
+}
+
+func TestParseWithRecursive(t *testing.T) {
+	parser := NewParser("WITH RECURSIVE r (id, depth) AS (SELECT id, 0 FROM staff WHERE manager IS NULL UNION ALL SELECT s.id, r.depth + 1 FROM staff AS s JOIN r ON s.manager = r.id) SELECT * FROM r;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*WithQuery)
+	assert.True(t, content.recursive)
+	assert.Len(t, content.tables, 1)
+	assert.Equal(t, SET_UNION, content.tables[0].query.(*CompoundSelect).operator)
+
+	parser = NewParser("WITH RECURSIVE a AS (SELECT k FROM t) SELECT k FROM a;")
+	assert.Len(t, parser.Parse(), 1)
+
+	parser = NewParser("WITH RECURSIVE r AS (SELECT k FROM t UNION SELECT k + 1 FROM t WHERE EXISTS (SELECT k FROM r)) SELECT k FROM r;")
+	assert.Len(t, parser.Parse(), 1)
+}
+
+func TestParseWithQueryErrors(t *testing.T) {
+	AssertParseError(t, "WITH a AS (SELECT k FROM t), a AS (SELECT k FROM u) SELECT k FROM a;", `WITH query name "a" specified more than once`)
+	AssertParseError(t, "WITH a (x, y) AS (SELECT k FROM t) SELECT x FROM a;", `WITH query "a" has 1 columns available but 2 columns specified`)
+	AssertParseError(t, "WITH a SELECT k FROM t;", "Expected AS")
+	AssertParseError(t, "WITH a AS SELECT k FROM t;", "Expected '('")
+	AssertParseError(t, "WITH a AS (SELECT k FROM t) UPDATE a SET k = 1;", "Expected SELECT")
+	AssertParseError(t, "WITH RECURSIVE r AS (SELECT k FROM r) SELECT k FROM r;", `Recursive query "r" does not have the form non-recursive-term UNION [ALL] recursive-term`)
+	AssertParseError(t, "WITH RECURSIVE r AS (SELECT k FROM t EXCEPT SELECT k FROM r) SELECT k FROM r;", `Recursive query "r" does not have the form non-recursive-term UNION [ALL] recursive-term`)
+	AssertParseError(t, "WITH RECURSIVE r AS (SELECT k FROM r UNION SELECT k FROM t) SELECT k FROM r;", `Recursive query "r" does not have the form non-recursive-term UNION [ALL] recursive-term`)
+	AssertParseError(t, "WITH RECURSIVE r AS (SELECT k FROM t WHERE EXISTS (SELECT k FROM r)) SELECT k FROM r;", `Recursive query "r" does not have the form non-recursive-term UNION [ALL] recursive-term`)
+	AssertParseError(t, "WITH RECURSIVE r AS (SELECT k FROM t WHERE k IN (SELECT k FROM r) UNION SELECT k FROM t) SELECT k FROM r;", `Recursive query "r" does not have the form non-recursive-term UNION [ALL] recursive-term`)
+	AssertParseError(t, "WITH RECURSIVE r AS (SELECT (SELECT max(k) FROM r) FROM t) SELECT k FROM r;", `Recursive query "r" does not have the form non-recursive-term UNION [ALL] recursive-term`)
+}
+
+func TestParseResultColumnAliases(t *testing.T) {
+	parser := NewParser("SELECT a AS x, b y, upper(b), t.c, EXISTS (SELECT * FROM u), 1 FROM t;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, ResultColumn{
+		&ColumnExpression{NODE_COLUMN_EXPRESSION, 7, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 7)},
+		lex.MakeToken(lex.TOKEN_IDENTIFIER, "x", 12),
+	}, content.columns[0])
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_IDENTIFIER, "y", 17), content.columns[1].alias)
+
+	var names []string
+	for _, column := range content.columns {
+		names = append(names, column.Name())
+	}
+	assert.Equal(t, []string{"x", "y", "upper", "c", "exists", "?column?"}, names)
+
+	parser = NewParser("UPDATE t SET a = 1 RETURNING a AS old;")
+	assert.Equal(t, "old", parser.Parse()[0].Content.(*UpdateStatement).returning[0].Name())
+
+	parser = NewParser("SELECT a, count(*) AS n FROM t GROUP BY a ORDER BY n DESC;")
+	assert.Len(t, parser.Parse(), 1)
+
+	AssertParseError(t, "SELECT a AS FROM t;", "Expected alias")
+	AssertParseError(t, "SELECT t.* AS x FROM t;", "Expected FROM")
+	AssertParseError(t, "SELECT a, count(*) AS n FROM t GROUP BY a ORDER BY b;", `Column "b" must appear in the GROUP BY clause or be used in an aggregate function`)
+}
+
+func TestParseArithmetic(t *testing.T) {
+	parser := NewParser("SELECT a + b * 2 || 'x' FROM t WHERE -a % 3 = b / 2 - 1;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	a := &ColumnExpression{NODE_COLUMN_EXPRESSION, 7, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 7)}
+	b := &ColumnExpression{NODE_COLUMN_EXPRESSION, 11, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "b", 11)}
+	assert.Equal(t, &BinaryExpression{
+		NODE_BINARY_EXPRESSION, 7, lex.MakeToken(lex.TOKEN_CONCAT, "", 17),
+		&BinaryExpression{
+			NODE_BINARY_EXPRESSION, 7, lex.MakeToken(lex.TOKEN_PLUS, "", 9),
+			a,
+			&BinaryExpression{
+				NODE_BINARY_EXPRESSION, 11, lex.MakeToken(lex.TOKEN_ASTERISK, "", 13),
+				b,
+				&LiteralExpression{NODE_INTEGER_VALUE, 15, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "2", 15)},
+			},
+		},
+		&LiteralExpression{NODE_TEXT_VALUE, 20, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "x", 20)},
+	}, content.columns[0].expression)
+
+	where := content.where.(*BinaryExpression)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_EQUAL, "", 44), where.operator)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_PERCENT, "", 40), where.left.(*BinaryExpression).operator)
+	assert.IsType(t, &UnaryExpression{}, where.left.(*BinaryExpression).left)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_MINUS, "", 52), where.right.(*BinaryExpression).operator)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_SLASH, "", 48), where.right.(*BinaryExpression).left.(*BinaryExpression).operator)
+}
+
+func TestParseCaseExpression(t *testing.T) {
+	parser := NewParser("SELECT CASE WHEN a > 0 THEN 'pos' WHEN a < 0 THEN 'neg' END, CASE a WHEN 1 THEN 'one' ELSE 'many' END FROM t;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	searched := content.columns[0].expression.(*CaseExpression)
+	assert.Equal(t, 7, searched.Pos())
+	assert.Nil(t, searched.operand)
+	assert.Len(t, searched.whens, 2)
+	assert.Equal(t, &LiteralExpression{NODE_TEXT_VALUE, 28, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "pos", 28)}, searched.whens[0].result)
+	assert.Nil(t, searched.else_result)
+
+	simple := content.columns[1].expression.(*CaseExpression)
+	assert.Equal(t, &CaseExpression{
+		NODE_CASE_EXPRESSION, 61,
+		&ColumnExpression{NODE_COLUMN_EXPRESSION, 66, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 66)},
+		[]WhenClause{{
+			&LiteralExpression{NODE_INTEGER_VALUE, 73, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 73)},
+			&LiteralExpression{NODE_TEXT_VALUE, 80, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "one", 80)},
+		}},
+		&LiteralExpression{NODE_TEXT_VALUE, 91, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "many", 91)},
+	}, simple)
+	assert.Equal(t, "case", content.columns[1].Name())
+
+	AssertParseError(t, "SELECT CASE END FROM t;", "Expected expression")
+	AssertParseError(t, "SELECT CASE ELSE 1 END FROM t;", "Expected expression")
+	AssertParseError(t, "SELECT CASE a THEN 1 END FROM t;", "Expected WHEN")
+	AssertParseError(t, "SELECT CASE WHEN a 1 END FROM t;", "Expected THEN")
+	AssertParseError(t, "SELECT CASE WHEN a THEN 1 FROM t;", "Expected END")
+}
+
+func TestParseCastExpression(t *testing.T) {
+	parser := NewParser("SELECT CAST(a AS DECIMAL(10, 2)), CAST('1' AS INTEGER) FROM t;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, &CastExpression{
+		NODE_CAST_EXPRESSION, 7,
+		&ColumnExpression{NODE_COLUMN_EXPRESSION, 12, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "a", 12)},
+		DataType{lex.MakeToken(lex.TOKEN_KEYWORD_DECIMAL, "", 17), []lex.Token{
+			lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "10", 25), lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "2", 29),
+		}},
+	}, content.columns[0].expression)
+	assert.Equal(t, "a", content.columns[0].Name())
+	assert.Equal(t, "?column?", content.columns[1].Name())
+
+	parser = NewParser("SELECT CAST(a AS TEXT), count(*) FROM t GROUP BY CAST(a AS TEXT);")
+	assert.Len(t, parser.Parse(), 1)
+
+	AssertParseError(t, "SELECT CAST(a TEXT) FROM t;", "Expected AS")
+	AssertParseError(t, "SELECT CAST(a AS VARCHAR) FROM t;", "Expected Type")
+	AssertParseError(t, "SELECT CAST a AS TEXT FROM t;", "Expected '('")
+	AssertParseError(t, "SELECT a FROM t GROUP BY a ORDER BY CAST(b AS TEXT);", `Column "b" must appear in the GROUP BY clause or be used in an aggregate function`)
+}
+
+func TestParseCastDataTypes(t *testing.T) {
+	parser := NewParser("SELECT CAST('123.456' AS DECIMAL(5, 2)), CAST('0.5' AS NUMBER(3)), CAST(1000 AS NUMBER(5, 2)), CAST('7' AS NUMBER), CAST('1' AS INTEGER), CAST('12.345' AS DECIMAL(0x0A, 2));")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	columns := result[0].Content.(*SelectStatement).columns
+	cast := func(i int) (types.Value, error) {
+		expression := columns[i].expression.(*CastExpression)
+		literal := expression.operand.(*LiteralExpression)
+
+		value := types.NewText(literal.value.Value())
+		if literal._type == NODE_INTEGER_VALUE {
+			value, _ = types.ParseInteger(literal.value.Value())
+		}
+
+		return expression.data_type.Cast(value)
+	}
+
+	value, err := cast(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "123.46", value.String())
+
+	value, err = cast(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", value.String())
+
+	_, err = cast(2)
+	assert.EqualError(t, err, "Numeric overflow: 1000 does not fit DECIMAL(5,2)")
+
+	value, err = cast(3)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TYPE_DECIMAL, value.Type())
+
+	value, err = cast(4)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NewInteger(1), value)
+
+	value, err = cast(5)
+	assert.NoError(t, err)
+	assert.Equal(t, "12.35", value.String())
+
+	AssertParseError(t, "SELECT CAST(a AS NUMBER(0)) FROM t;", "DECIMAL precision must be between 1 and 38")
+}
+
+func TestParseScalarFunctions(t *testing.T) {
+	parser := NewParser("SELECT coalesce(a, b, 0), substr(name, 1, 3), round(price * 1.1, 2) FROM t;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Len(t, content.columns[0].expression.(*FunctionExpression).arguments, 3)
+
+	AssertParseError(t, "SELECT frobnicate(a) FROM t;", "Function frobnicate does not exist")
+	AssertParseError(t, "SELECT upper(a, b) FROM t;", "Function upper takes exactly one argument")
+	AssertParseError(t, "SELECT coalesce() FROM t;", "Function coalesce takes at least one argument")
+	AssertParseError(t, "SELECT substr(a) FROM t;", "Function substr takes 2 to 3 arguments")
+}
+
+func TestParseUserDefinedFunctions(t *testing.T) {
+	identity := func(arguments []types.Value) (types.Value, error) { return arguments[0], nil }
+	assert.NoError(t, functions.RegisterScalar("parser_identity", 1, true, identity))
+	step := func(state any, _ []types.Value) (any, error) { return state, nil }
+	final := func(any) (types.Value, error) { return types.NewNull(), nil }
+	assert.NoError(t, functions.RegisterAggregate("parser_concat", 1, step, final))
+	assert.NoError(t, functions.RegisterAggregate("parser_corr", 2, step, final))
+	assert.NoError(t, functions.RegisterScalar("left", 2, true, identity))
+
+	parser := NewParser("SELECT parser_identity(a) + 1, parser_concat(b) OVER (ORDER BY a) FROM t WHERE parser_identity(a) > 0;")
+	assert.Len(t, parser.Parse(), 1)
+
+	parser = NewParser("SELECT a, parser_concat(DISTINCT b) FROM t GROUP BY a HAVING parser_concat(b) IS NOT NULL;")
+	assert.Len(t, parser.Parse(), 1)
+
+	AssertParseError(t, "SELECT parser_identity(a, b) FROM t;", "Function parser_identity takes exactly one argument")
+	AssertParseError(t, "SELECT * FROM t WHERE parser_concat(b) IS NULL;", "Aggregate functions are not allowed in WHERE")
+	AssertParseError(t, "SELECT parser_concat(a, b) FROM t;", "Function parser_concat takes exactly one argument")
+
+	parser = NewParser("SELECT parser_corr(a, b), left('abc', 2) FROM t;")
+	assert.Len(t, parser.Parse(), 1)
+
+	AssertParseError(t, "SELECT parser_corr(a) FROM t;", "Function parser_corr takes exactly 2 arguments")
+}
+
+func TestParseDateTimeFunctions(t *testing.T) {
+	parser := NewParser("SELECT EXTRACT(year FROM created), current_timestamp, t.current_date FROM t WHERE created > now() - INTERVAL '7 days';")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, &FunctionExpression{
+		_type: NODE_FUNCTION_EXPRESSION, start: 7, name: lex.MakeToken(lex.TOKEN_IDENTIFIER, "extract", 7),
+		arguments: []Expression{
+			&LiteralExpression{NODE_TEXT_VALUE, 15, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "year", 15)},
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 25, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "created", 25)},
+		},
+	}, content.columns[0].expression)
+	assert.Equal(t, "extract", content.columns[0].Name())
+	assert.Equal(t, &FunctionExpression{
+		_type: NODE_FUNCTION_EXPRESSION, start: 35, name: lex.MakeToken(lex.TOKEN_IDENTIFIER, "current_timestamp", 35),
+	}, content.columns[1].expression)
+	assert.IsType(t, &ColumnExpression{}, content.columns[2].expression)
+	assert.Equal(t,
+		&LiteralExpression{NODE_INTERVAL_VALUE, 100, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "7 days", 109)},
+		content.where.(*BinaryExpression).right.(*BinaryExpression).right,
+	)
+
+	parser = NewParser("SELECT CAST('1 hour' AS INTERVAL), date_trunc('month', a), strftime('%Y', a) FROM t GROUP BY date_trunc('month', a), a;")
+	assert.Len(t, parser.Parse(), 1)
+
+	parser = NewParser("SELECT \"current_date\", `current_time`, \"count\" FROM t GROUP BY \"current_date\", `current_time`, \"count\";")
+	result = parser.Parse()
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, []ResultColumn{
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 7, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "current_date", 7)}, lex.Token{}},
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 23, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "current_time", 23)}, lex.Token{}},
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 39, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "count", 39)}, lex.Token{}},
+	}, result[0].Content.(*SelectStatement).columns)
+
+	AssertParseError(t, "SELECT EXTRACT(fortnight FROM a) FROM t;", "Unit 'fortnight' not recognized")
+	AssertParseError(t, "SELECT EXTRACT(year, a) FROM t;", "Expected FROM")
+	AssertParseError(t, "SELECT EXTRACT('year' FROM a) FROM t;", "Expected field name")
+	AssertParseError(t, "SELECT \"extract\"(year FROM a) FROM t;", "Expected ',' or ')")
+	AssertParseError(t, "SELECT INTERVAL '3 fortnights' FROM t;", "Invalid INTERVAL value: '3 fortnights'")
+	AssertParseError(t, "SELECT now(1) FROM t;", "Function now takes exactly 0 arguments")
+}
+
+func TestParseWindowFunctions(t *testing.T) {
+	parser := NewParser("SELECT id, row_number() OVER (PARTITION BY dept ORDER BY salary DESC), sum(salary) OVER (ORDER BY id ROWS BETWEEN 2 PRECEDING AND CURRENT ROW) FROM t;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Equal(t, &WindowExpression{
+		NODE_WINDOW_EXPRESSION, 11,
+		&FunctionExpression{_type: NODE_FUNCTION_EXPRESSION, start: 11, name: lex.MakeToken(lex.TOKEN_IDENTIFIER, "row_number", 11)},
+		WindowDefinition{
+			[]Expression{&ColumnExpression{NODE_COLUMN_EXPRESSION, 43, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "dept", 43)}},
+			[]OrderingTerm{{&ColumnExpression{NODE_COLUMN_EXPRESSION, 57, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "salary", 57)}, true, true}},
+			WindowFrame{functions.FRAME_RANGE, FrameBound{kind: functions.BOUND_UNBOUNDED_PRECEDING}, FrameBound{kind: functions.BOUND_CURRENT_ROW}},
+		},
+	}, content.columns[1].expression)
+	assert.Equal(t, "row_number", content.columns[1].Name())
+	assert.Equal(t, WindowFrame{
+		functions.FRAME_ROWS,
+		FrameBound{functions.BOUND_PRECEDING, &LiteralExpression{NODE_INTEGER_VALUE, 114, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "2", 114)}},
+		FrameBound{kind: functions.BOUND_CURRENT_ROW},
+	}, content.columns[2].expression.(*WindowExpression).window.frame)
+
+	for _, source := range []string{
+		"SELECT count(*) OVER (), rank() OVER (ORDER BY a), lag(a, 2, 0) OVER (ORDER BY b) FROM t;",
+		"SELECT first_value(a) OVER (ORDER BY b RANGE BETWEEN INTERVAL '1 day' PRECEDING AND UNBOUNDED FOLLOWING) FROM t;",
+		"SELECT last_value(a) OVER (ROWS UNBOUNDED PRECEDING) FROM t ORDER BY rank() OVER (ORDER BY a);",
+		"SELECT a, sum(count(*)) OVER (ORDER BY a) FROM t GROUP BY a;",
+		"SELECT avg(a) OVER (ROWS BETWEEN CURRENT ROW AND $1 FOLLOWING) FROM t;",
+	} {
+		parser = NewParser(source)
+		assert.Len(t, parser.Parse(), 1, source)
+	}
+
+	AssertParseError(t, "SELECT rank() FROM t;", "Window function rank requires an OVER clause")
+	AssertParseError(t, "SELECT upper(a) OVER () FROM t;", "OVER specified, but upper is not a window function nor an aggregate function")
+	AssertParseError(t, "SELECT count(DISTINCT a) OVER () FROM t;", "DISTINCT is not implemented for window functions")
+	AssertParseError(t, "SELECT lag() OVER () FROM t;", "Function lag takes 1 to 3 arguments")
+	AssertParseError(t, "SELECT rank() OVER FROM t;", "Expected '('")
+	AssertParseError(t, "SELECT rank() OVER (PARTITION dept) FROM t;", "Expected BY")
+	AssertParseError(t, "SELECT rank() OVER (ORDER BY a ROWS 1) FROM t;", "Expected PRECEDING or FOLLOWING")
+	AssertParseError(t, "SELECT rank() OVER (ORDER BY a ROWS CURRENT) FROM t;", "Expected ROW")
+	AssertParseError(t, "SELECT sum(a) OVER (ROWS UNBOUNDED FOLLOWING) FROM t;", "Frame start cannot be UNBOUNDED FOLLOWING")
+	AssertParseError(t, "SELECT sum(a) OVER (ROWS BETWEEN CURRENT ROW AND UNBOUNDED PRECEDING) FROM t;", "Frame end cannot be UNBOUNDED PRECEDING")
+	AssertParseError(t, "SELECT sum(a) OVER (ROWS BETWEEN CURRENT ROW AND 1 PRECEDING) FROM t;", "Frame starting from current row cannot have preceding rows")
+	AssertParseError(t, "SELECT sum(a) OVER (ROWS BETWEEN 1 FOLLOWING AND CURRENT ROW) FROM t;", "Frame starting from following row cannot have preceding rows")
+	AssertParseError(t, "SELECT sum(a) OVER (RANGE 1 PRECEDING) FROM t;", "RANGE with offset PRECEDING/FOLLOWING requires exactly one ORDER BY column")
+	AssertParseError(t, "SELECT sum(a) OVER (ORDER BY a ROWS b PRECEDING) FROM t;", "Argument of ROWS must not contain variables")
+	AssertParseError(t, "SELECT sum(a) OVER (PARTITION BY rank() OVER ()) FROM t;", "Window functions are not allowed in window definitions")
+	AssertParseError(t, "SELECT * FROM t WHERE rank() OVER () = 1;", "Window functions are not allowed in WHERE")
+	AssertParseError(t, "SELECT a FROM t GROUP BY a HAVING rank() OVER () = 1;", "Window functions are not allowed in HAVING")
+	AssertParseError(t, "SELECT sum(rank() OVER ()) FROM t;", "Aggregate function calls cannot contain window function calls")
+	AssertParseError(t, "SELECT lag(rank() OVER ()) OVER () FROM t;", "Window function calls cannot be nested")
+	AssertParseError(t, "SELECT a, sum(b) OVER () FROM t GROUP BY a;", `Column "b" must appear in the GROUP BY clause or be used in an aggregate function`)
+}
+
+func TestParsePatternMatching(t *testing.T) {
+	parser := NewParser("SELECT * FROM t WHERE name NOT LIKE 'a!%%' ESCAPE '!' AND code ILIKE $1 AND path GLOB '*.go' AND id REGEXP '^[0-9]+$';")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	where := content.where.(*BinaryExpression)
+	regexp := where.right.(*PatternExpression)
+	glob := where.left.(*BinaryExpression).right.(*PatternExpression)
+	ilike := where.left.(*BinaryExpression).left.(*BinaryExpression).right.(*PatternExpression)
+	like := where.left.(*BinaryExpression).left.(*BinaryExpression).left.(*PatternExpression)
+
+	assert.Equal(t, &PatternExpression{
+		NODE_PATTERN_EXPRESSION, 22, lex.MakeToken(lex.TOKEN_KEYWORD_LIKE, "", 31),
+		&ColumnExpression{NODE_COLUMN_EXPRESSION, 22, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "name", 22)},
+		&LiteralExpression{NODE_TEXT_VALUE, 36, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "a!%%", 36)},
+		&LiteralExpression{NODE_TEXT_VALUE, 50, lex.MakeToken(lex.TOKEN_LITERAL_TEXT, "!", 50)},
+		true,
+	}, like)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_KEYWORD_ILIKE, "", 63), ilike.operator)
+	assert.IsType(t, &ParameterExpression{}, ilike.pattern)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_KEYWORD_GLOB, "", 81), glob.operator)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_KEYWORD_REGEXP, "", 100), regexp.operator)
+	assert.False(t, regexp.negated)
+
+	AssertParseError(t, "SELECT * FROM t WHERE a LIKE 'a\\';", "LIKE pattern must not end with escape character")
+	AssertParseError(t, "SELECT * FROM t WHERE a LIKE 'a' ESCAPE 'ab';", "Invalid escape string")
+	AssertParseError(t, "SELECT * FROM t WHERE a GLOB 'a' ESCAPE '!';", "ESCAPE is only allowed with LIKE and ILIKE")
+	AssertParseError(t, "SELECT * FROM t WHERE a GLOB '[a';", "Unterminated '[' in GLOB pattern")
+	AssertParseError(t, "SELECT * FROM t WHERE a REGEXP '(';", "Invalid regular expression: missing closing ): `(`")
+}
+
+func TestParseBetweenAndInList(t *testing.T) {
+	parser := NewParser("SELECT * FROM t WHERE a BETWEEN 1 AND b + 1 AND c NOT IN (1, 2, d) AND e NOT BETWEEN 'a' AND 'm';")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	where := content.where.(*BinaryExpression)
+	left := where.left.(*BinaryExpression)
+
+	between := left.left.(*BetweenExpression)
+	assert.Equal(t, 22, between.Pos())
+	assert.Equal(t, &LiteralExpression{NODE_INTEGER_VALUE, 32, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 32)}, between.low)
+	assert.IsType(t, &BinaryExpression{}, between.high)
+	assert.False(t, between.negated)
+
+	assert.Equal(t, &InExpression{
+		NODE_IN_EXPRESSION, 48,
+		&ColumnExpression{NODE_COLUMN_EXPRESSION, 48, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "c", 48)},
+		nil,
+		[]Expression{
+			&LiteralExpression{NODE_INTEGER_VALUE, 58, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "1", 58)},
+			&LiteralExpression{NODE_INTEGER_VALUE, 61, lex.MakeToken(lex.TOKEN_LITERAL_INTEGER, "2", 61)},
+			&ColumnExpression{NODE_COLUMN_EXPRESSION, 64, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "d", 64)},
+		},
+		true,
+	}, left.right)
+	assert.True(t, where.right.(*BetweenExpression).negated)
+
+	parser = NewParser("SELECT a IN (1, 2), count(*) FROM t GROUP BY a IN (1, 2);")
+	assert.Len(t, parser.Parse(), 1)
+
+	AssertParseError(t, "SELECT * FROM t WHERE a BETWEEN 1 OR 2;", "Expected AND")
+	AssertParseError(t, "SELECT * FROM t WHERE a IN (1, 2;", "Expected ',' or ')")
+	AssertParseError(t, "SELECT a FROM t GROUP BY a ORDER BY a IN (b);", `Column "b" must appear in the GROUP BY clause or be used in an aggregate function`)
+}
+
+func TestParseUnreservedKeywordsAsNames(t *testing.T) {
+	names := []string{"date", "time", "timestamp", "key", "no", "action", "cascade", "deferred", "immediate", "restrict", "set", "always", "generated", "identity", "conflict", "nothing", "first", "last", "nulls", "full", "left", "right", "end", "current", "following", "partition", "preceding", "range", "row", "rows", "unbounded", "index"}
+	for _, name := range names {
+		parser := NewParser(strings.ReplaceAll("CREATE TABLE x (x INTEGER); SELECT x, t.x FROM x AS t WHERE x > 1 ORDER BY x; UPDATE x SET x = 1; INSERT INTO x (x) VALUES (1);", "x", name))
+		assert.Len(t, parser.Parse(), 4, name)
+	}
+
+	parser := NewParser("CREATE TABLE t (date DATE, time TIME);")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*CreateTableStatement)
+	assert.Equal(t, []lex.Token{lex.MakeToken(lex.TOKEN_IDENTIFIER, "date", 16), lex.MakeToken(lex.TOKEN_IDENTIFIER, "time", 27)}, content.column_names)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_KEYWORD_DATE, "", 21), content.column_types[0].name)
+
+	parser = NewParser("SELECT date, t.time FROM t WHERE date > DATE '2024-01-01';")
+	result = parser.Parse()
+
+	assert.Len(t, result, 1)
+	content_select := result[0].Content.(*SelectStatement)
+	assert.Equal(t, []ResultColumn{
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 7, lex.Token{}, lex.MakeToken(lex.TOKEN_IDENTIFIER, "date", 7)}, lex.Token{}},
+		{&ColumnExpression{NODE_COLUMN_EXPRESSION, 13, lex.MakeToken(lex.TOKEN_IDENTIFIER, "t", 13), lex.MakeToken(lex.TOKEN_IDENTIFIER, "time", 15)}, lex.Token{}},
+	}, content_select.columns)
+	assert.IsType(t, &LiteralExpression{}, content_select.where.(*BinaryExpression).right)
+
+	parser = NewParser("SELECT * FROM t LEFT JOIN u ON t.left = u.right;")
+	result = parser.Parse()
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, JOIN_LEFT, result[0].Content.(*SelectStatement).from.(*JoinClause).join_type)
+
+	parser = NewParser("CREATE INDEX index ON index (index); DROP INDEX index;")
+	assert.Len(t, parser.Parse(), 2)
+
+	AssertParseError(t, "SELECT a FROM t left;", "Expected JOIN")
+}
+
+func TestParseSelectWithoutFrom(t *testing.T) {
+	parser := NewParser("SELECT upper('x'), CAST('1' AS INTEGER), current_date, 1 + 2 AS three;")
+	result := parser.Parse()
+
+	assert.Len(t, result, 1)
+	content := result[0].Content.(*SelectStatement)
+	assert.Nil(t, content.from)
+	assert.Len(t, content.columns, 4)
+	assert.Equal(t, lex.MakeToken(lex.TOKEN_IDENTIFIER, "three", 64), content.columns[3].alias)
+
+	parser = NewParser("SELECT 1 WHERE TRUE UNION SELECT 2 ORDER BY 1; SELECT a FROM t WHERE EXISTS (SELECT a); INSERT INTO t SELECT 1; SELECT 1 AS x ORDER BY x;")
+	assert.Len(t, parser.Parse(), 4)
+
+	AssertParseError(t, "SELECT a;", `Column "a" does not exist`)
+	AssertParseError(t, "SELECT t.a;", `Missing FROM-clause entry for table "t"`)
+	AssertParseError(t, "SELECT 1 WHERE a > 1;", `Column "a" does not exist`)
+	AssertParseError(t, "SELECT *;", "SELECT * with no tables specified is not valid")
+	AssertParseError(t, "SELECT 1 ORDER BY x;", `Column "x" does not exist`)
+	AssertParseError(t, "SELECT 1 LIMIT n;", `Column "n" does not exist`)
+	AssertParseError(t, "SELECT 1 UNION SELECT a;", `Column "a" does not exist`)
+	AssertParseError(t, "SELECT x FROM (SELECT a) AS s;", `Column "a" does not exist`)
+	AssertParseError(t, "SELECT 1 2;", "Expected FROM")
+}
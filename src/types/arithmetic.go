@@ -0,0 +1,214 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrIntegerOutOfRange = errors.New("INTEGER out of range")
+	ErrRealOutOfRange    = errors.New("REAL out of range")
+	ErrDivisionByZero    = errors.New("Division by zero")
+)
+
+type numeric_operation struct {
+	symbol  string
+	integer func(int64, int64) (int64, error)
+	decimal func(Decimal, Decimal) (Decimal, error)
+	real    func(float64, float64) (float64, error)
+}
+
+var (
+	addition = numeric_operation{
+		"+",
+		add_integer,
+		func(left Decimal, right Decimal) (Decimal, error) { return left.Add(right), nil },
+		func(left float64, right float64) (float64, error) { return left + right, nil },
+	}
+	subtraction = numeric_operation{
+		"-",
+		subtract_integer,
+		func(left Decimal, right Decimal) (Decimal, error) { return left.Subtract(right), nil },
+		func(left float64, right float64) (float64, error) { return left - right, nil },
+	}
+	multiplication = numeric_operation{
+		"*",
+		multiply_integer,
+		func(left Decimal, right Decimal) (Decimal, error) { return left.Multiply(right), nil },
+		func(left float64, right float64) (float64, error) { return left * right, nil },
+	}
+	division = numeric_operation{
+		"/",
+		divide_integer,
+		func(left Decimal, right Decimal) (Decimal, error) { return left.Divide(right) },
+		divide_real,
+	}
+	modulo = numeric_operation{
+		"%",
+		modulo_integer,
+		func(left Decimal, right Decimal) (Decimal, error) { return left.Remainder(right) },
+		modulo_real,
+	}
+)
+
+func Add(left Value, right Value) (Value, error) {
+	if is_temporal_operand(left) || is_temporal_operand(right) {
+		return add_temporal(left, right)
+	}
+
+	return apply_numeric(addition, left, right)
+}
+
+func Subtract(left Value, right Value) (Value, error) {
+	if is_temporal_operand(left) || is_temporal_operand(right) {
+		return subtract_temporal(left, right)
+	}
+
+	return apply_numeric(subtraction, left, right)
+}
+
+func Multiply(left Value, right Value) (Value, error) {
+	return apply_numeric(multiplication, left, right)
+}
+
+func Divide(left Value, right Value) (Value, error) {
+	return apply_numeric(division, left, right)
+}
+
+func Modulo(left Value, right Value) (Value, error) {
+	return apply_numeric(modulo, left, right)
+}
+
+func Negate(value Value) (Value, error) {
+	switch value._type {
+	case TYPE_NULL:
+		return value, nil
+	case TYPE_INTEGER:
+		if value.integer == math.MinInt64 {
+			return Value{}, ErrIntegerOutOfRange
+		}
+		return NewInteger(-value.integer), nil
+	case TYPE_DECIMAL:
+		return NewDecimal(value.decimal.Negate()), nil
+	case TYPE_REAL:
+		return NewReal(-value.real), nil
+	case TYPE_INTERVAL:
+		return negate_interval(value)
+	default:
+		return Value{}, fmt.Errorf("Cannot apply '-' to %s", value._type)
+	}
+}
+
+func apply_numeric(operation numeric_operation, left Value, right Value) (Value, error) {
+	switch {
+	case !is_numeric_operand(left) || !is_numeric_operand(right):
+		return Value{}, fmt.Errorf("Cannot apply '%s' to %s and %s", operation.symbol, left._type, right._type)
+	case left.IsNull() || right.IsNull():
+		return NewNull(), nil
+	case left._type == TYPE_REAL || right._type == TYPE_REAL:
+		result, err := operation.real(to_real(left), to_real(right))
+		if err != nil {
+			return Value{}, err
+		}
+
+		if math.IsInf(result, 0) || math.IsNaN(result) {
+			return Value{}, ErrRealOutOfRange
+		}
+
+		return NewReal(result), nil
+	case left._type == TYPE_DECIMAL || right._type == TYPE_DECIMAL:
+		result, err := operation.decimal(to_decimal(left), to_decimal(right))
+		if err != nil {
+			return Value{}, err
+		}
+
+		return NewDecimal(result), nil
+	default:
+		result, err := operation.integer(left.integer, right.integer)
+		if err != nil {
+			return Value{}, err
+		}
+
+		return NewInteger(result), nil
+	}
+}
+
+func is_numeric_operand(value Value) bool {
+	return value.IsNull() || value._type.IsNumeric()
+}
+
+func to_decimal(value Value) Decimal {
+	if value._type == TYPE_INTEGER {
+		return MakeDecimal(value.integer, 0)
+	}
+
+	return value.decimal
+}
+
+func add_integer(left int64, right int64) (int64, error) {
+	result := left + right
+	if (left > 0 && right > 0 && result < 0) || (left < 0 && right < 0 && result >= 0) {
+		return 0, ErrIntegerOutOfRange
+	}
+
+	return result, nil
+}
+
+func subtract_integer(left int64, right int64) (int64, error) {
+	result := left - right
+	if (left >= 0 && right < 0 && result < 0) || (left < 0 && right > 0 && result >= 0) {
+		return 0, ErrIntegerOutOfRange
+	}
+
+	return result, nil
+}
+
+func multiply_integer(left int64, right int64) (int64, error) {
+	if left == 0 || right == 0 {
+		return 0, nil
+	}
+
+	result := left * right
+	if result/right != left || (left == -1 && right == math.MinInt64) || (right == -1 && left == math.MinInt64) {
+		return 0, ErrIntegerOutOfRange
+	}
+
+	return result, nil
+}
+
+func divide_integer(left int64, right int64) (int64, error) {
+	if right == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	if left == math.MinInt64 && right == -1 {
+		return 0, ErrIntegerOutOfRange
+	}
+
+	return left / right, nil
+}
+
+func divide_real(left float64, right float64) (float64, error) {
+	if right == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	return left / right, nil
+}
+
+func modulo_integer(left int64, right int64) (int64, error) {
+	if right == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	return left % right, nil
+}
+
+func modulo_real(left float64, right float64) (float64, error) {
+	if right == 0 {
+		return 0, ErrDivisionByZero
+	}
+
+	return math.Mod(left, right), nil
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArithmeticInteger(t *testing.T) {
+	result, err := Add(NewInteger(2), NewInteger(3))
+	assert.NoError(t, err)
+	assert.Equal(t, NewInteger(5), result)
+
+	result, err = Subtract(NewInteger(2), NewInteger(3))
+	assert.NoError(t, err)
+	assert.Equal(t, NewInteger(-1), result)
+
+	result, err = Multiply(NewInteger(-4), NewInteger(3))
+	assert.NoError(t, err)
+	assert.Equal(t, NewInteger(-12), result)
+
+	result, err = Divide(NewInteger(7), NewInteger(-2))
+	assert.NoError(t, err)
+	assert.Equal(t, NewInteger(-3), result)
+
+	result, err = Modulo(NewInteger(-7), NewInteger(3))
+	assert.NoError(t, err)
+	assert.Equal(t, NewInteger(-1), result)
+}
+
+func TestArithmeticIntegerOverflow(t *testing.T) {
+	max, min := NewInteger(math.MaxInt64), NewInteger(math.MinInt64)
+
+	_, err := Add(max, NewInteger(1))
+	assert.Equal(t, ErrIntegerOutOfRange, err)
+
+	_, err = Subtract(min, NewInteger(1))
+	assert.Equal(t, ErrIntegerOutOfRange, err)
+
+	_, err = Subtract(NewInteger(0), min)
+	assert.Equal(t, ErrIntegerOutOfRange, err)
+
+	_, err = Multiply(max, NewInteger(2))
+	assert.Equal(t, ErrIntegerOutOfRange, err)
+
+	_, err = Multiply(NewInteger(-1), min)
+	assert.Equal(t, ErrIntegerOutOfRange, err)
+
+	_, err = Divide(min, NewInteger(-1))
+	assert.Equal(t, ErrIntegerOutOfRange, err)
+
+	_, err = Negate(min)
+	assert.Equal(t, ErrIntegerOutOfRange, err)
+
+	result, err := Add(min, max)
+	assert.NoError(t, err)
+	assert.Equal(t, NewInteger(-1), result)
+}
+
+func TestArithmeticPromotion(t *testing.T) {
+	result, err := Add(NewInteger(1), NewReal(0.5))
+	assert.NoError(t, err)
+	assert.Equal(t, NewReal(1.5), result)
+
+	result, err = Multiply(NewInteger(3), NewDecimal(MakeDecimal(125, 2)))
+	assert.NoError(t, err)
+	assert.Equal(t, TYPE_DECIMAL, result.Type())
+	assert.Equal(t, "3.75", result.String())
+
+	result, err = Subtract(NewDecimal(MakeDecimal(5, 1)), NewReal(0.25))
+	assert.NoError(t, err)
+	assert.Equal(t, NewReal(0.25), result)
+}
+
+func TestArithmeticErrors(t *testing.T) {
+	_, err := Divide(NewInteger(1), NewInteger(0))
+	assert.Equal(t, ErrDivisionByZero, err)
+
+	_, err = Divide(NewReal(1), NewInteger(0))
+	assert.Equal(t, ErrDivisionByZero, err)
+
+	_, err = Divide(NewDecimal(MakeDecimal(1, 0)), NewInteger(0))
+	assert.Equal(t, ErrDivisionByZero, err)
+
+	_, err = Modulo(NewInteger(1), NewInteger(0))
+	assert.Equal(t, ErrDivisionByZero, err)
+
+	_, err = Modulo(NewReal(1), NewReal(0))
+	assert.Equal(t, ErrDivisionByZero, err)
+
+	_, err = Multiply(NewReal(math.MaxFloat64), NewReal(2))
+	assert.Equal(t, ErrRealOutOfRange, err)
+
+	_, err = Add(NewText("a"), NewInteger(1))
+	assert.EqualError(t, err, "Cannot apply '+' to TEXT and INTEGER")
+
+	_, err = Negate(NewBoolean(true))
+	assert.EqualError(t, err, "Cannot apply '-' to BOOLEAN")
+}
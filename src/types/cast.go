@@ -0,0 +1,184 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func Cast(value Value, target Type) (Value, error) {
+	if value.IsNull() || value._type == target {
+		return value, nil
+	}
+
+	switch target {
+	case TYPE_INTEGER:
+		return cast_integer(value)
+	case TYPE_REAL:
+		return cast_real(value)
+	case TYPE_DECIMAL:
+		return cast_decimal(value)
+	case TYPE_TEXT:
+		if value._type == TYPE_BLOB {
+			return NewText(value.bytes), nil
+		}
+
+		return NewText(value.String()), nil
+	case TYPE_BOOLEAN:
+		return cast_boolean(value)
+	case TYPE_BLOB:
+		if value._type == TYPE_TEXT {
+			return NewBlob([]byte(value.bytes)), nil
+		}
+	case TYPE_DATE:
+		switch value._type {
+		case TYPE_TEXT:
+			return ParseDate(strings.TrimSpace(value.bytes))
+		case TYPE_TIMESTAMP:
+			return NewDate(floor_divide(value.integer, MICROSECONDS_PER_DAY)), nil
+		}
+	case TYPE_TIME:
+		switch value._type {
+		case TYPE_TEXT:
+			return ParseTime(strings.TrimSpace(value.bytes))
+		case TYPE_TIMESTAMP:
+			return NewTime(value.integer - floor_divide(value.integer, MICROSECONDS_PER_DAY)*MICROSECONDS_PER_DAY), nil
+		}
+	case TYPE_TIMESTAMP:
+		switch value._type {
+		case TYPE_TEXT:
+			return ParseTimestamp(strings.TrimSpace(value.bytes))
+		case TYPE_DATE:
+			microseconds, err := multiply_integer(value.integer, MICROSECONDS_PER_DAY)
+			if err != nil {
+				return Value{}, err
+			}
+
+			return NewTimestamp(microseconds), nil
+		}
+	case TYPE_INTERVAL:
+		switch value._type {
+		case TYPE_TEXT:
+			return ParseInterval(strings.TrimSpace(value.bytes))
+		case TYPE_TIME:
+			return NewInterval(0, value.integer), nil
+		}
+	}
+
+	return Value{}, cast_error(value, target)
+}
+
+func CastDecimal(value Value, precision int, scale int) (Value, error) {
+	result, err := Cast(value, TYPE_DECIMAL)
+	if err != nil || result.IsNull() {
+		return result, err
+	}
+
+	decimal, err := result.decimal.CheckPrecision(precision, scale)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return NewDecimal(decimal), nil
+}
+
+func Concat(left Value, right Value) (Value, error) {
+	if left.IsNull() || right.IsNull() {
+		return NewNull(), nil
+	}
+
+	if left._type == TYPE_BLOB && right._type == TYPE_BLOB {
+		return NewBlob([]byte(left.bytes + right.bytes)), nil
+	}
+
+	left, _ = Cast(left, TYPE_TEXT)
+	right, _ = Cast(right, TYPE_TEXT)
+
+	return NewText(left.bytes + right.bytes), nil
+}
+
+func cast_integer(value Value) (Value, error) {
+	switch value._type {
+	case TYPE_REAL:
+		rounded := math.Round(value.real)
+		if math.IsNaN(rounded) || rounded < math.MinInt64 || rounded >= math.MaxInt64 {
+			return Value{}, ErrIntegerOutOfRange
+		}
+
+		return NewInteger(int64(rounded)), nil
+	case TYPE_DECIMAL:
+		rounded := value.decimal.Round(0).coefficient()
+		if !rounded.IsInt64() {
+			return Value{}, ErrIntegerOutOfRange
+		}
+
+		return NewInteger(rounded.Int64()), nil
+	case TYPE_BOOLEAN:
+		return NewInteger(value.integer), nil
+	case TYPE_TEXT:
+		return ParseInteger(strings.TrimSpace(value.bytes))
+	}
+
+	return Value{}, cast_error(value, TYPE_INTEGER)
+}
+
+func cast_real(value Value) (Value, error) {
+	switch value._type {
+	case TYPE_INTEGER, TYPE_DECIMAL:
+		return NewReal(to_real(value)), nil
+	case TYPE_TEXT:
+		return ParseReal(strings.TrimSpace(value.bytes))
+	}
+
+	return Value{}, cast_error(value, TYPE_REAL)
+}
+
+func cast_decimal(value Value) (Value, error) {
+	switch value._type {
+	case TYPE_INTEGER:
+		return NewDecimal(MakeDecimal(value.integer, 0)), nil
+	case TYPE_REAL:
+		if math.IsNaN(value.real) || math.IsInf(value.real, 0) {
+			return Value{}, fmt.Errorf("Cannot cast %s to DECIMAL", value)
+		}
+
+		decimal, err := ParseDecimal(strconv.FormatFloat(value.real, 'f', -1, 64))
+		if err != nil {
+			return Value{}, err
+		}
+
+		return NewDecimal(decimal), nil
+	case TYPE_TEXT:
+		decimal, err := ParseDecimal(strings.TrimSpace(value.bytes))
+		if err != nil {
+			return Value{}, err
+		}
+
+		return NewDecimal(decimal), nil
+	}
+
+	return Value{}, cast_error(value, TYPE_DECIMAL)
+}
+
+func cast_boolean(value Value) (Value, error) {
+	switch value._type {
+	case TYPE_INTEGER:
+		return NewBoolean(value.integer != 0), nil
+	case TYPE_TEXT:
+		switch strings.ToLower(strings.TrimSpace(value.bytes)) {
+		case "true", "t", "yes", "y", "on", "1":
+			return NewBoolean(true), nil
+		case "false", "f", "no", "n", "off", "0":
+			return NewBoolean(false), nil
+		}
+
+		return Value{}, fmt.Errorf("Invalid BOOLEAN value: '%s'", value.bytes)
+	}
+
+	return Value{}, cast_error(value, TYPE_BOOLEAN)
+}
+
+func cast_error(value Value, target Type) error {
+	return fmt.Errorf("Cannot cast %s to %s", value._type, target)
+}
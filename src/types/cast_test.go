@@ -0,0 +1,116 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCast(t *testing.T) {
+	cases := []struct {
+		value    Value
+		target   Type
+		expected string
+	}{
+		{NewInteger(42), TYPE_TEXT, "42"},
+		{NewInteger(42), TYPE_REAL, "42"},
+		{NewInteger(42), TYPE_DECIMAL, "42"},
+		{NewInteger(0), TYPE_BOOLEAN, "FALSE"},
+		{NewReal(2.5), TYPE_INTEGER, "3"},
+		{NewReal(-2.5), TYPE_INTEGER, "-3"},
+		{NewReal(0.1), TYPE_DECIMAL, "0.1"},
+		{NewDecimal(MakeDecimal(-2345, 3)), TYPE_INTEGER, "-2"},
+		{NewBoolean(true), TYPE_INTEGER, "1"},
+		{NewBoolean(true), TYPE_TEXT, "TRUE"},
+		{NewText(" 17 "), TYPE_INTEGER, "17"},
+		{NewText("1.5e2"), TYPE_REAL, "150"},
+		{NewText("12.50"), TYPE_DECIMAL, "12.50"},
+		{NewText("Yes"), TYPE_BOOLEAN, "TRUE"},
+		{NewText("off"), TYPE_BOOLEAN, "FALSE"},
+		{NewText("ab"), TYPE_BLOB, "X'6162'"},
+		{NewBlob([]byte("ab")), TYPE_TEXT, "ab"},
+		{NewText("2024-02-29"), TYPE_DATE, "2024-02-29"},
+		{NewText("2024-02-29 12:30"), TYPE_TIMESTAMP, "2024-02-29 12:30:00"},
+		{NewTimestamp(-MICROSECONDS_PER_SECOND), TYPE_DATE, "1969-12-31"},
+		{NewTimestamp(-MICROSECONDS_PER_SECOND), TYPE_TIME, "23:59:59"},
+		{NewDate(1), TYPE_TIMESTAMP, "1970-01-02 00:00:00"},
+		{NewText(" 2 hours "), TYPE_INTERVAL, "02:00:00"},
+		{NewTime(90 * MICROSECONDS_PER_SECOND), TYPE_INTERVAL, "00:01:30"},
+		{NewInterval(1, MICROSECONDS_PER_DAY), TYPE_TEXT, "1 mon 1 day"},
+		{NewNull(), TYPE_INTEGER, "NULL"},
+	}
+
+	for _, test := range cases {
+		result, err := Cast(test.value, test.target)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, result.String(), test.value.String()+" AS "+test.target.String())
+		if !test.value.IsNull() {
+			assert.Equal(t, test.target, result.Type())
+		}
+	}
+}
+
+func TestCastDecimal(t *testing.T) {
+	cases := []struct {
+		value     Value
+		precision int
+		scale     int
+		expected  string
+	}{
+		{NewText("123.456"), 5, 2, "123.46"},
+		{NewText("-123.455"), 5, 2, "-123.46"},
+		{NewReal(0.125), 3, 2, "0.13"},
+		{NewInteger(42), 4, 2, "42.00"},
+		{NewNull(), 5, 2, "NULL"},
+	}
+
+	for _, test := range cases {
+		result, err := CastDecimal(test.value, test.precision, test.scale)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, result.String())
+	}
+
+	_, err := CastDecimal(NewInteger(1000), 5, 2)
+	assert.EqualError(t, err, "Numeric overflow: 1000 does not fit DECIMAL(5,2)")
+
+	_, err = CastDecimal(NewText("99.5"), 2, 0)
+	assert.EqualError(t, err, "Numeric overflow: 99.5 does not fit DECIMAL(2,0)")
+
+	_, err = CastDecimal(NewText("abc"), 5, 2)
+	assert.EqualError(t, err, "Invalid DECIMAL value: 'abc'")
+}
+
+func TestCastErrors(t *testing.T) {
+	_, err := Cast(NewText("abc"), TYPE_INTEGER)
+	assert.EqualError(t, err, "Invalid INTEGER value: 'abc'")
+
+	_, err = Cast(NewText("maybe"), TYPE_BOOLEAN)
+	assert.EqualError(t, err, "Invalid BOOLEAN value: 'maybe'")
+
+	_, err = Cast(NewReal(1e19), TYPE_INTEGER)
+	assert.Equal(t, ErrIntegerOutOfRange, err)
+
+	_, err = Cast(NewReal(math.NaN()), TYPE_DECIMAL)
+	assert.EqualError(t, err, "Cannot cast NaN to DECIMAL")
+
+	_, err = Cast(NewBoolean(true), TYPE_REAL)
+	assert.EqualError(t, err, "Cannot cast BOOLEAN to REAL")
+
+	_, err = Cast(NewDate(0), TYPE_INTEGER)
+	assert.EqualError(t, err, "Cannot cast DATE to INTEGER")
+}
+
+func TestConcat(t *testing.T) {
+	result, err := Concat(NewText("a"), NewInteger(1))
+	assert.NoError(t, err)
+	assert.Equal(t, NewText("a1"), result)
+
+	result, err = Concat(NewBlob([]byte{1}), NewBlob([]byte{2}))
+	assert.NoError(t, err)
+	assert.Equal(t, NewBlob([]byte{1, 2}), result)
+
+	result, err = Concat(NewText("a"), NewNull())
+	assert.NoError(t, err)
+	assert.Equal(t, NewNull(), result)
+}
@@ -0,0 +1,256 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	MAX_DECIMAL_PRECISION  = 38
+	DECIMAL_DIVISION_SCALE = 6
+)
+
+var big_ten = big.NewInt(10)
+
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+func MakeDecimal(unscaled int64, scale int32) Decimal {
+	return Decimal{big.NewInt(unscaled), scale}
+}
+
+func ParseDecimal(text string) (Decimal, error) {
+	mantissa := text
+	exponent := 0
+
+	if index := strings.IndexAny(mantissa, "eE"); index >= 0 {
+		value, err := strconv.Atoi(mantissa[index+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("Invalid DECIMAL value: '%s'", text)
+		}
+
+		if value > MAX_DECIMAL_PRECISION || value < -MAX_DECIMAL_PRECISION {
+			return Decimal{}, fmt.Errorf("DECIMAL exponent out of range: '%s'", text)
+		}
+
+		mantissa, exponent = mantissa[:index], value
+	}
+
+	integer_part, fraction_part, _ := strings.Cut(mantissa, ".")
+	digits := strings.TrimLeft(integer_part, "+-")
+
+	if digits+fraction_part == "" || strings.ContainsAny(digits+fraction_part, "+-") {
+		return Decimal{}, fmt.Errorf("Invalid DECIMAL value: '%s'", text)
+	}
+
+	unscaled, ok := new(big.Int).SetString(integer_part+fraction_part, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("Invalid DECIMAL value: '%s'", text)
+	}
+
+	scale := len(fraction_part) - exponent
+	if scale > math.MaxInt32 {
+		return Decimal{}, fmt.Errorf("DECIMAL scale out of range: '%s'", text)
+	}
+
+	if scale < 0 {
+		unscaled.Mul(unscaled, power_of_ten(-scale))
+		scale = 0
+	}
+
+	return Decimal{unscaled, int32(scale)}, nil
+}
+
+func power_of_ten(exponent int) *big.Int {
+	return new(big.Int).Exp(big_ten, big.NewInt(int64(exponent)), nil)
+}
+
+func (decimal Decimal) coefficient() *big.Int {
+	if decimal.unscaled == nil {
+		return new(big.Int)
+	}
+
+	return decimal.unscaled
+}
+
+func (decimal Decimal) Scale() int32 {
+	return decimal.scale
+}
+
+func (decimal Decimal) Sign() int {
+	return decimal.coefficient().Sign()
+}
+
+func (decimal Decimal) String() string {
+	digits := new(big.Int).Abs(decimal.coefficient()).String()
+	sign := ""
+	if decimal.Sign() < 0 {
+		sign = "-"
+	}
+
+	if decimal.scale <= 0 {
+		return sign + digits
+	}
+
+	scale := int(decimal.scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+func (decimal Decimal) Rat() *big.Rat {
+	denominator := power_of_ten(int(decimal.scale))
+	return new(big.Rat).SetFrac(decimal.coefficient(), denominator)
+}
+
+func (decimal Decimal) Float64() float64 {
+	value, _ := decimal.Rat().Float64()
+	return value
+}
+
+func (decimal Decimal) Rescale(scale int32) Decimal {
+	if scale >= decimal.scale {
+		unscaled := new(big.Int).Mul(decimal.coefficient(), power_of_ten(int(scale-decimal.scale)))
+		return Decimal{unscaled, scale}
+	}
+
+	return decimal_from_rat(decimal.Rat(), scale)
+}
+
+func (decimal Decimal) Normalize() Decimal {
+	unscaled, scale := decimal.coefficient(), decimal.scale
+	for scale > 0 {
+		quotient, remainder := new(big.Int).QuoRem(unscaled, big_ten, new(big.Int))
+		if remainder.Sign() != 0 {
+			break
+		}
+
+		unscaled, scale = quotient, scale-1
+	}
+
+	return Decimal{unscaled, scale}
+}
+
+func (decimal Decimal) CheckPrecision(precision int, scale int) (Decimal, error) {
+	rescaled := decimal.Rescale(int32(scale))
+
+	if len(new(big.Int).Abs(rescaled.coefficient()).String()) > precision {
+		return Decimal{}, fmt.Errorf("Numeric overflow: %s does not fit DECIMAL(%d,%d)", decimal, precision, scale)
+	}
+
+	return rescaled, nil
+}
+
+func (decimal Decimal) Negate() Decimal {
+	return Decimal{new(big.Int).Neg(decimal.coefficient()), decimal.scale}
+}
+
+func (decimal Decimal) Add(other Decimal) Decimal {
+	left, right := align_scales(decimal, other)
+	return Decimal{new(big.Int).Add(left.coefficient(), right.coefficient()), left.scale}
+}
+
+func (decimal Decimal) Subtract(other Decimal) Decimal {
+	left, right := align_scales(decimal, other)
+	return Decimal{new(big.Int).Sub(left.coefficient(), right.coefficient()), left.scale}
+}
+
+func (decimal Decimal) Multiply(other Decimal) Decimal {
+	return Decimal{new(big.Int).Mul(decimal.coefficient(), other.coefficient()), decimal.scale + other.scale}
+}
+
+func (decimal Decimal) Divide(other Decimal) (Decimal, error) {
+	if other.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+
+	scale := max_scale(max_scale(decimal.scale, other.scale), DECIMAL_DIVISION_SCALE)
+	quotient := new(big.Rat).Quo(decimal.Rat(), other.Rat())
+
+	return decimal_from_rat(quotient, scale), nil
+}
+
+func (decimal Decimal) Remainder(other Decimal) (Decimal, error) {
+	if other.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+
+	left, right := align_scales(decimal, other)
+	return Decimal{new(big.Int).Rem(left.coefficient(), right.coefficient()), left.scale}, nil
+}
+
+func (decimal Decimal) Round(scale int32) Decimal {
+	if scale >= decimal.scale {
+		return decimal
+	}
+
+	if scale >= 0 {
+		return decimal_from_rat(decimal.Rat(), scale)
+	}
+
+	digits := len(new(big.Int).Abs(decimal.coefficient()).String()) - int(decimal.scale)
+	if digits < -int(scale) {
+		return MakeDecimal(0, 0)
+	}
+
+	factor := power_of_ten(-int(scale))
+	rounded := decimal_from_rat(new(big.Rat).Quo(decimal.Rat(), new(big.Rat).SetInt(factor)), 0)
+
+	return Decimal{rounded.unscaled.Mul(rounded.unscaled, factor), 0}
+}
+
+func (decimal Decimal) Floor() Decimal {
+	if decimal.scale <= 0 {
+		return decimal
+	}
+
+	quotient, _ := new(big.Int).DivMod(decimal.coefficient(), power_of_ten(int(decimal.scale)), new(big.Int))
+	return Decimal{quotient, 0}
+}
+
+func (decimal Decimal) Ceil() Decimal {
+	return decimal.Negate().Floor().Negate()
+}
+
+func (decimal Decimal) Compare(other Decimal) int {
+	left, right := align_scales(decimal, other)
+	return left.coefficient().Cmp(right.coefficient())
+}
+
+func align_scales(left Decimal, right Decimal) (Decimal, Decimal) {
+	scale := max_scale(left.scale, right.scale)
+	return left.Rescale(scale), right.Rescale(scale)
+}
+
+func max_scale(left int32, right int32) int32 {
+	if left > right {
+		return left
+	}
+
+	return right
+}
+
+func decimal_from_rat(value *big.Rat, scale int32) Decimal {
+	numerator := new(big.Int).Mul(value.Num(), power_of_ten(int(scale)))
+	denominator := value.Denom()
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+
+	remainder.Abs(remainder).Lsh(remainder, 1)
+	if remainder.Cmp(denominator) >= 0 {
+		if numerator.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return Decimal{quotient, scale}
+}
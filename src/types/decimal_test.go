@@ -0,0 +1,162 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]string{
+		"0":                                 "0",
+		"12.50":                             "12.50",
+		"-0.001":                            "-0.001",
+		"+.5":                               "0.5",
+		"1.5e3":                             "1500",
+		"1.25e-2":                           "0.0125",
+		"000123.40":                         "123.40",
+		"123456789012345678901234567890.12": "123456789012345678901234567890.12",
+	}
+
+	for text, expected := range cases {
+		value, err := ParseDecimal(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, value.String(), text)
+	}
+}
+
+func TestParseDecimalErrors(t *testing.T) {
+	for _, text := range []string{"", ".", "1.2.3", "--1", "1e", "abc", "1-2"} {
+		_, err := ParseDecimal(text)
+		assert.EqualError(t, err, "Invalid DECIMAL value: '"+text+"'")
+	}
+
+	for _, text := range []string{"1e999999999", "1e-999999999", "1e39", "1.5e-39", "1e2147483648"} {
+		_, err := ParseDecimal(text)
+		assert.Error(t, err, text)
+	}
+
+	_, err := ParseDecimal("1e99999999999999999999")
+	assert.EqualError(t, err, "Invalid DECIMAL value: '1e99999999999999999999'")
+
+	_, err = ParseDecimal("1e999999999")
+	assert.EqualError(t, err, "DECIMAL exponent out of range: '1e999999999'")
+}
+
+func TestParseDecimalExponentLimits(t *testing.T) {
+	value, err := ParseDecimal("1e38")
+	assert.NoError(t, err)
+	assert.Equal(t, "1"+strings.Repeat("0", 38), value.String())
+
+	value, err = ParseDecimal("1e-38")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(38), value.Scale())
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, _ := ParseDecimal("10.25")
+	b, _ := ParseDecimal("0.1")
+
+	assert.Equal(t, "10.35", a.Add(b).String())
+	assert.Equal(t, "10.15", a.Subtract(b).String())
+	assert.Equal(t, "1.025", a.Multiply(b).String())
+	assert.Equal(t, "-10.25", a.Negate().String())
+
+	quotient, err := a.Divide(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "102.500000", quotient.String())
+
+	third, err := MakeDecimal(1, 0).Divide(MakeDecimal(3, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.333333", third.String())
+
+	two_thirds, err := MakeDecimal(-2, 0).Divide(MakeDecimal(3, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "-0.666667", two_thirds.String())
+
+	_, err = a.Divide(Decimal{})
+	assert.Equal(t, ErrDivisionByZero, err)
+}
+
+func TestDecimalMoneyIsExact(t *testing.T) {
+	total := MakeDecimal(0, 2)
+	cent, _ := ParseDecimal("0.01")
+
+	for i := 0; i < 1000; i++ {
+		total = total.Add(cent)
+	}
+
+	assert.Equal(t, "10.00", total.String())
+}
+
+func TestDecimalRescale(t *testing.T) {
+	value, _ := ParseDecimal("2.345")
+
+	assert.Equal(t, "2.35", value.Rescale(2).String())
+	assert.Equal(t, "2", value.Rescale(0).String())
+	assert.Equal(t, "2.34500", value.Rescale(5).String())
+	assert.Equal(t, "-2.35", value.Negate().Rescale(2).String())
+}
+
+func TestDecimalNormalize(t *testing.T) {
+	for text, expected := range map[string]string{"1.00": "1", "-2.50": "-2.5", "0.000": "0", "100": "100", "0.05": "0.05"} {
+		value, _ := ParseDecimal(text)
+		assert.Equal(t, expected, value.Normalize().String(), text)
+	}
+}
+
+func TestDecimalCheckPrecision(t *testing.T) {
+	value, _ := ParseDecimal("123.456")
+
+	fitted, err := value.CheckPrecision(5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "123.46", fitted.String())
+
+	_, err = value.CheckPrecision(4, 2)
+	assert.EqualError(t, err, "Numeric overflow: 123.456 does not fit DECIMAL(4,2)")
+
+	small, _ := ParseDecimal("0.05")
+	fitted, err = small.CheckPrecision(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.05", fitted.String())
+}
+
+func TestDecimalCompare(t *testing.T) {
+	a, _ := ParseDecimal("1.50")
+	b, _ := ParseDecimal("1.5")
+	c, _ := ParseDecimal("-3")
+
+	assert.Equal(t, 0, a.Compare(b))
+	assert.Equal(t, 1, a.Compare(c))
+	assert.Equal(t, -1, c.Compare(Decimal{}))
+}
+
+func TestDecimalRounding(t *testing.T) {
+	value, _ := ParseDecimal("1234.567")
+
+	assert.Equal(t, "1234.57", value.Round(2).String())
+	assert.Equal(t, "1234.567", value.Round(5).String())
+	assert.Equal(t, "1200", value.Round(-2).String())
+	assert.Equal(t, "0", value.Round(-10).String())
+	assert.Equal(t, "1234", value.Floor().String())
+	assert.Equal(t, "1235", value.Ceil().String())
+	assert.Equal(t, "-1235", value.Negate().Floor().String())
+	assert.Equal(t, "-1234", value.Negate().Ceil().String())
+}
+
+func TestDecimalRemainder(t *testing.T) {
+	left, _ := ParseDecimal("7.5")
+	right, _ := ParseDecimal("2")
+
+	result, err := left.Remainder(right)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", result.String())
+
+	result, err = left.Negate().Remainder(right)
+	assert.NoError(t, err)
+	assert.Equal(t, "-1.5", result.String())
+
+	_, err = left.Remainder(MakeDecimal(0, 0))
+	assert.Equal(t, ErrDivisionByZero, err)
+}
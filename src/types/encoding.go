@@ -0,0 +1,118 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+const (
+	DECIMAL_SIGN_POSITIVE = 0
+	DECIMAL_SIGN_NEGATIVE = 1
+)
+
+func Encode(buffer []byte, value Value) []byte {
+	switch value._type {
+	case TYPE_NULL:
+		return buffer
+	case TYPE_REAL:
+		return binary.BigEndian.AppendUint64(buffer, math.Float64bits(value.real))
+	case TYPE_DECIMAL:
+		magnitude := new(big.Int).Abs(value.decimal.coefficient()).Bytes()
+		sign := byte(DECIMAL_SIGN_POSITIVE)
+		if value.decimal.Sign() < 0 {
+			sign = DECIMAL_SIGN_NEGATIVE
+		}
+
+		buffer = binary.AppendUvarint(buffer, uint64(value.decimal.scale))
+		buffer = append(buffer, sign)
+		buffer = binary.AppendUvarint(buffer, uint64(len(magnitude)))
+		return append(buffer, magnitude...)
+	case TYPE_TEXT, TYPE_BLOB:
+		buffer = binary.AppendUvarint(buffer, uint64(len(value.bytes)))
+		return append(buffer, value.bytes...)
+	case TYPE_BOOLEAN:
+		return append(buffer, byte(value.integer))
+	case TYPE_INTERVAL:
+		buffer = binary.AppendVarint(buffer, value.months)
+		return binary.AppendVarint(buffer, value.integer)
+	default:
+		return binary.AppendVarint(buffer, value.integer)
+	}
+}
+
+func Decode(_type Type, buffer []byte) (Value, int, error) {
+	invalid := fmt.Errorf("Invalid %s encoding", _type)
+
+	switch _type {
+	case TYPE_NULL:
+		return NewNull(), 0, nil
+	case TYPE_REAL:
+		if len(buffer) < 8 {
+			return Value{}, 0, invalid
+		}
+
+		return NewReal(math.Float64frombits(binary.BigEndian.Uint64(buffer))), 8, nil
+	case TYPE_DECIMAL:
+		scale, scale_width := binary.Uvarint(buffer)
+		if scale_width <= 0 || scale > math.MaxInt32 || len(buffer) <= scale_width {
+			return Value{}, 0, invalid
+		}
+
+		sign := buffer[scale_width]
+		magnitude, width, ok := decode_bytes(buffer[scale_width+1:])
+		if !ok || sign > DECIMAL_SIGN_NEGATIVE {
+			return Value{}, 0, invalid
+		}
+
+		unscaled := new(big.Int).SetBytes(magnitude)
+		if sign == DECIMAL_SIGN_NEGATIVE {
+			unscaled.Neg(unscaled)
+		}
+
+		return NewDecimal(Decimal{unscaled, int32(scale)}), scale_width + 1 + width, nil
+	case TYPE_TEXT, TYPE_BLOB:
+		bytes, width, ok := decode_bytes(buffer)
+		if !ok {
+			return Value{}, 0, invalid
+		}
+
+		return Value{_type: _type, bytes: string(bytes)}, width, nil
+	case TYPE_BOOLEAN:
+		if len(buffer) < 1 || buffer[0] > 1 {
+			return Value{}, 0, invalid
+		}
+
+		return NewBoolean(buffer[0] == 1), 1, nil
+	case TYPE_INTERVAL:
+		months, months_width := binary.Varint(buffer)
+		if months_width <= 0 {
+			return Value{}, 0, invalid
+		}
+
+		microseconds, width := binary.Varint(buffer[months_width:])
+		if width <= 0 {
+			return Value{}, 0, invalid
+		}
+
+		return NewInterval(months, microseconds), months_width + width, nil
+	default:
+		integer, width := binary.Varint(buffer)
+		if width <= 0 {
+			return Value{}, 0, invalid
+		}
+
+		return Value{_type: _type, integer: integer}, width, nil
+	}
+}
+
+func decode_bytes(buffer []byte) ([]byte, int, bool) {
+	length, width := binary.Uvarint(buffer)
+	if width <= 0 || length > uint64(len(buffer)-width) {
+		return nil, 0, false
+	}
+
+	end := width + int(length)
+	return buffer[width:end], end, true
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	decimal, _ := ParseDecimal("-12345678901234567890.123")
+	date, _ := ParseDate("1901-05-17")
+	time, _ := ParseTime("23:59:59.999999")
+	timestamp, _ := ParseTimestamp("2038-01-19 03:14:08")
+	interval, _ := ParseInterval("-1 year 3 days 04:05:06.000007")
+
+	values := []Value{
+		NewInteger(0), NewInteger(math.MinInt64), NewInteger(math.MaxInt64),
+		NewReal(-0.5), NewReal(math.Inf(1)),
+		NewDecimal(decimal), NewDecimal(MakeDecimal(0, 2)),
+		NewText(""), NewText("héllo"), NewBlob([]byte{0, 1, 255}),
+		NewBoolean(true), NewBoolean(false),
+		date, time, timestamp, interval,
+	}
+
+	var buffer []byte
+	for _, value := range values {
+		buffer = Encode(buffer, value)
+	}
+
+	for _, expected := range values {
+		value, width, err := Decode(expected.Type(), buffer)
+		assert.NoError(t, err)
+
+		result, err := Compare(expected, value)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result, expected.String())
+		assert.Equal(t, expected.String(), value.String())
+
+		buffer = buffer[width:]
+	}
+
+	assert.Empty(t, buffer)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	_, _, err := Decode(TYPE_REAL, []byte{1, 2, 3})
+	assert.EqualError(t, err, "Invalid REAL encoding")
+
+	_, _, err = Decode(TYPE_TEXT, []byte{5, 'a'})
+	assert.EqualError(t, err, "Invalid TEXT encoding")
+
+	_, _, err = Decode(TYPE_BOOLEAN, []byte{2})
+	assert.EqualError(t, err, "Invalid BOOLEAN encoding")
+
+	_, _, err = Decode(TYPE_DECIMAL, []byte{0, 7, 0})
+	assert.EqualError(t, err, "Invalid DECIMAL encoding")
+
+	_, _, err = Decode(TYPE_INTERVAL, []byte{2})
+	assert.EqualError(t, err, "Invalid INTERVAL encoding")
+
+	_, _, err = Decode(TYPE_INTEGER, nil)
+	assert.EqualError(t, err, "Invalid INTEGER encoding")
+}
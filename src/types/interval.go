@@ -0,0 +1,353 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MONTHS_PER_YEAR         = 12
+	DAYS_PER_MONTH          = 30
+	MICROSECONDS_PER_MILLI  = 1_000
+	MICROSECONDS_PER_MINUTE = 60 * MICROSECONDS_PER_SECOND
+	MICROSECONDS_PER_HOUR   = 60 * MICROSECONDS_PER_MINUTE
+	MICROSECONDS_PER_WEEK   = 7 * MICROSECONDS_PER_DAY
+	MAX_YEAR                = 294_000
+)
+
+var (
+	ErrTimestampOutOfRange = errors.New("TIMESTAMP out of range")
+	ErrIntervalOutOfRange  = errors.New("INTERVAL out of range")
+)
+
+type interval_unit struct {
+	months       int64
+	microseconds int64
+}
+
+var interval_units = map[string]interval_unit{
+	"microsecond": {0, 1},
+	"us":          {0, 1},
+	"usec":        {0, 1},
+	"millisecond": {0, MICROSECONDS_PER_MILLI},
+	"ms":          {0, MICROSECONDS_PER_MILLI},
+	"msec":        {0, MICROSECONDS_PER_MILLI},
+	"second":      {0, MICROSECONDS_PER_SECOND},
+	"s":           {0, MICROSECONDS_PER_SECOND},
+	"sec":         {0, MICROSECONDS_PER_SECOND},
+	"minute":      {0, MICROSECONDS_PER_MINUTE},
+	"m":           {0, MICROSECONDS_PER_MINUTE},
+	"min":         {0, MICROSECONDS_PER_MINUTE},
+	"hour":        {0, MICROSECONDS_PER_HOUR},
+	"h":           {0, MICROSECONDS_PER_HOUR},
+	"hr":          {0, MICROSECONDS_PER_HOUR},
+	"day":         {0, MICROSECONDS_PER_DAY},
+	"d":           {0, MICROSECONDS_PER_DAY},
+	"week":        {0, MICROSECONDS_PER_WEEK},
+	"w":           {0, MICROSECONDS_PER_WEEK},
+	"month":       {1, 0},
+	"mon":         {1, 0},
+	"year":        {MONTHS_PER_YEAR, 0},
+	"y":           {MONTHS_PER_YEAR, 0},
+	"yr":          {MONTHS_PER_YEAR, 0},
+	"decade":      {10 * MONTHS_PER_YEAR, 0},
+	"century":     {100 * MONTHS_PER_YEAR, 0},
+	"centuries":   {100 * MONTHS_PER_YEAR, 0},
+	"millennium":  {1000 * MONTHS_PER_YEAR, 0},
+	"millennia":   {1000 * MONTHS_PER_YEAR, 0},
+}
+
+func ParseInterval(text string) (Value, error) {
+	invalid := fmt.Errorf("Invalid INTERVAL value: '%s'", text)
+	out_of_range := fmt.Errorf("INTERVAL value out of range: '%s'", text)
+
+	fields := strings.Fields(strings.ToLower(text))
+	ago := len(fields) > 0 && fields[len(fields)-1] == "ago"
+	if ago {
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return Value{}, invalid
+	}
+
+	months, microseconds := 0.0, 0.0
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			clock, ok := parse_clock(fields[i])
+			if !ok {
+				return Value{}, invalid
+			}
+
+			microseconds += clock
+			continue
+		}
+
+		quantity, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil || i+1 == len(fields) || math.IsInf(quantity, 0) || math.IsNaN(quantity) {
+			return Value{}, invalid
+		}
+
+		i += 1
+		unit, ok := interval_units[fields[i]]
+		if !ok {
+			unit, ok = interval_units[strings.TrimSuffix(fields[i], "s")]
+		}
+		if !ok {
+			return Value{}, invalid
+		}
+
+		whole := math.Trunc(quantity * float64(unit.months))
+		months += whole
+		microseconds += (quantity*float64(unit.months) - whole) * DAYS_PER_MONTH * MICROSECONDS_PER_DAY
+		microseconds += quantity * float64(unit.microseconds)
+	}
+
+	if ago {
+		months, microseconds = -months, -microseconds
+	}
+
+	microseconds = math.Round(microseconds)
+	if math.Abs(months) >= math.MaxInt32 || math.Abs(microseconds) >= math.MaxInt64 {
+		return Value{}, out_of_range
+	}
+
+	return NewInterval(int64(months), int64(microseconds)), nil
+}
+
+func parse_clock(text string) (float64, bool) {
+	sign := 1.0
+	if strings.HasPrefix(text, "-") {
+		sign, text = -1, text[1:]
+	} else {
+		text = strings.TrimPrefix(text, "+")
+	}
+
+	parts := strings.Split(text, ":")
+	if len(parts) > 3 {
+		return 0, false
+	}
+
+	scales := []float64{MICROSECONDS_PER_HOUR, MICROSECONDS_PER_MINUTE, MICROSECONDS_PER_SECOND}
+	total := 0.0
+	for i, part := range parts {
+		if part == "" || strings.ContainsAny(part, "+-eE") {
+			return 0, false
+		}
+
+		quantity, err := strconv.ParseFloat(part, 64)
+		if err != nil || (i < len(parts)-1 && strings.Contains(part, ".")) {
+			return 0, false
+		}
+
+		total += quantity * scales[i]
+	}
+
+	return sign * total, true
+}
+
+func format_interval(value Value) string {
+	var parts []string
+	append_part := func(quantity int64, singular string, plural string) {
+		switch quantity {
+		case 0:
+		case 1:
+			parts = append(parts, "1 "+singular)
+		default:
+			parts = append(parts, strconv.FormatInt(quantity, 10)+" "+plural)
+		}
+	}
+
+	append_part(value.months/MONTHS_PER_YEAR, "year", "years")
+	append_part(value.months%MONTHS_PER_YEAR, "mon", "mons")
+	append_part(value.integer/MICROSECONDS_PER_DAY, "day", "days")
+
+	clock := value.integer % MICROSECONDS_PER_DAY
+	if clock != 0 || len(parts) == 0 {
+		sign := ""
+		if clock < 0 {
+			sign, clock = "-", -clock
+		}
+
+		text := fmt.Sprintf("%s%02d:%02d:%02d", sign, clock/MICROSECONDS_PER_HOUR, clock%MICROSECONDS_PER_HOUR/MICROSECONDS_PER_MINUTE, clock%MICROSECONDS_PER_MINUTE/MICROSECONDS_PER_SECOND)
+		if fraction := clock % MICROSECONDS_PER_SECOND; fraction != 0 {
+			text += strings.TrimRight(fmt.Sprintf(".%06d", fraction), "0")
+		}
+
+		parts = append(parts, text)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func interval_span(value Value) *big.Int {
+	span := big.NewInt(value.months)
+	span.Mul(span, big.NewInt(DAYS_PER_MONTH*MICROSECONDS_PER_DAY))
+	return span.Add(span, big.NewInt(value.integer))
+}
+
+func is_temporal_operand(value Value) bool {
+	return value._type.IsTemporal() || value._type == TYPE_INTERVAL
+}
+
+func add_temporal(left Value, right Value) (Value, error) {
+	mismatch := fmt.Errorf("Cannot apply '+' to %s and %s", left._type, right._type)
+
+	switch right._type {
+	case TYPE_INTERVAL, TYPE_INTEGER, TYPE_TIME, TYPE_NULL:
+		return shift_temporal(left, right, mismatch)
+	}
+
+	return shift_temporal(right, left, mismatch)
+}
+
+func shift_temporal(left Value, right Value, mismatch error) (Value, error) {
+	switch {
+	case left.IsNull() || right.IsNull():
+		return NewNull(), nil
+	case left._type == TYPE_INTERVAL && right._type == TYPE_INTERVAL:
+		return add_intervals(left, right)
+	case (left._type == TYPE_DATE || left._type == TYPE_TIMESTAMP) && right._type == TYPE_INTERVAL:
+		return shift_timestamp(left, right.months, right.integer)
+	case left._type == TYPE_TIME && right._type == TYPE_INTERVAL:
+		return NewTime(wrap_time(left.integer + right.integer%MICROSECONDS_PER_DAY)), nil
+	case left._type == TYPE_DATE && right._type == TYPE_INTEGER:
+		days, err := add_integer(left.integer, right.integer)
+		if err != nil {
+			return Value{}, ErrTimestampOutOfRange
+		}
+
+		return NewDate(days), nil
+	case left._type == TYPE_DATE && right._type == TYPE_TIME:
+		return shift_timestamp(left, 0, right.integer)
+	}
+
+	return Value{}, mismatch
+}
+
+func subtract_temporal(left Value, right Value) (Value, error) {
+	switch {
+	case left.IsNull() || right.IsNull():
+		return NewNull(), nil
+	case right._type == TYPE_INTERVAL && is_temporal_operand(left):
+		negated, err := Negate(right)
+		if err != nil {
+			return Value{}, err
+		}
+
+		return add_temporal(left, negated)
+	case left._type == TYPE_DATE && right._type == TYPE_DATE:
+		return apply_numeric(subtraction, NewInteger(left.integer), NewInteger(right.integer))
+	case left._type == TYPE_DATE && right._type == TYPE_INTEGER:
+		days, err := subtract_integer(left.integer, right.integer)
+		if err != nil {
+			return Value{}, ErrTimestampOutOfRange
+		}
+
+		return NewDate(days), nil
+	case left._type == TYPE_TIME && right._type == TYPE_TIME:
+		return NewInterval(0, left.integer-right.integer), nil
+	case is_instant(left) && is_instant(right):
+		left_microseconds, err := instant_microseconds(left)
+		if err != nil {
+			return Value{}, err
+		}
+
+		right_microseconds, err := instant_microseconds(right)
+		if err != nil {
+			return Value{}, err
+		}
+
+		microseconds, err := subtract_integer(left_microseconds, right_microseconds)
+		if err != nil {
+			return Value{}, ErrIntervalOutOfRange
+		}
+
+		return NewInterval(0, microseconds), nil
+	}
+
+	return Value{}, fmt.Errorf("Cannot apply '-' to %s and %s", left._type, right._type)
+}
+
+func negate_interval(value Value) (Value, error) {
+	if value.months == math.MinInt64 || value.integer == math.MinInt64 {
+		return Value{}, ErrIntervalOutOfRange
+	}
+
+	return NewInterval(-value.months, -value.integer), nil
+}
+
+func add_intervals(left Value, right Value) (Value, error) {
+	months, err := add_integer(left.months, right.months)
+	if err != nil {
+		return Value{}, ErrIntervalOutOfRange
+	}
+
+	microseconds, err := add_integer(left.integer, right.integer)
+	if err != nil {
+		return Value{}, ErrIntervalOutOfRange
+	}
+
+	return NewInterval(months, microseconds), nil
+}
+
+func is_instant(value Value) bool {
+	return value._type == TYPE_DATE || value._type == TYPE_TIMESTAMP
+}
+
+func instant_microseconds(value Value) (int64, error) {
+	if value._type == TYPE_TIMESTAMP {
+		return value.integer, nil
+	}
+
+	microseconds, err := multiply_integer(value.integer, MICROSECONDS_PER_DAY)
+	if err != nil {
+		return 0, ErrTimestampOutOfRange
+	}
+
+	return microseconds, nil
+}
+
+func shift_timestamp(value Value, months int64, microseconds int64) (Value, error) {
+	instant, err := instant_microseconds(value)
+	if err != nil {
+		return Value{}, err
+	}
+
+	if months != 0 {
+		moment := time.UnixMicro(instant).UTC()
+		year, month, day := moment.Date()
+
+		total := int64(year)*MONTHS_PER_YEAR + int64(month) - 1 + months
+		if total/MONTHS_PER_YEAR > MAX_YEAR || total/MONTHS_PER_YEAR < -MAX_YEAR {
+			return Value{}, ErrTimestampOutOfRange
+		}
+
+		year, month = int(floor_divide(total, MONTHS_PER_YEAR)), time.Month(total-floor_divide(total, MONTHS_PER_YEAR)*MONTHS_PER_YEAR+1)
+		if last := days_in_month(year, month); day > last {
+			day = last
+		}
+
+		clock := instant - floor_divide(instant, MICROSECONDS_PER_DAY)*MICROSECONDS_PER_DAY
+		instant = time.Date(year, month, day, 0, 0, 0, 0, time.UTC).UnixMicro() + clock
+	}
+
+	result, err := add_integer(instant, microseconds)
+	if err != nil {
+		return Value{}, ErrTimestampOutOfRange
+	}
+
+	return NewTimestamp(result), nil
+}
+
+func days_in_month(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func wrap_time(microseconds int64) int64 {
+	return microseconds - floor_divide(microseconds, MICROSECONDS_PER_DAY)*MICROSECONDS_PER_DAY
+}
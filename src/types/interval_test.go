@@ -0,0 +1,105 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"1 day", "1 day"},
+		{"3 days 04:05:06.5", "3 days 04:05:06.5"},
+		{"1 year 2 months", "1 year 2 mons"},
+		{"90 minutes", "01:30:00"},
+		{"1.5 hours", "01:30:00"},
+		{"2 weeks", "14 days"},
+		{"1.5 months", "1 mon 15 days"},
+		{"-02:00", "-02:00:00"},
+		{"1 decade 3 ms", "10 years 00:00:00.003"},
+		{"2 days ago", "-2 days"},
+		{"0 seconds", "00:00:00"},
+	}
+
+	for _, test := range tests {
+		value, err := ParseInterval(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.expected, value.String(), test.text)
+	}
+
+	for _, text := range []string{"", "day", "1", "1 fortnight", "1:2:3:4", "ago", "1:-2"} {
+		_, err := ParseInterval(text)
+		assert.EqualError(t, err, "Invalid INTERVAL value: '"+text+"'", text)
+	}
+}
+
+func TestCompareIntervals(t *testing.T) {
+	month, _ := ParseInterval("1 month")
+	days, _ := ParseInterval("30 days")
+	hours, _ := ParseInterval("721 hours")
+
+	result, err := Compare(month, days)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+
+	result, err = Compare(month, hours)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, result)
+
+	_, err = Compare(month, NewInteger(1))
+	assert.EqualError(t, err, "Cannot compare INTERVAL with INTEGER")
+}
+
+func TestTemporalArithmetic(t *testing.T) {
+	date, _ := ParseDate("2024-01-31")
+	timestamp, _ := ParseTimestamp("2024-03-10 12:00:00")
+	later, _ := ParseTimestamp("2024-03-12 18:30:00")
+	time, _ := ParseTime("23:30:00")
+	month, _ := ParseInterval("1 month")
+	hours, _ := ParseInterval("2 hours")
+
+	tests := []struct {
+		operation func(Value, Value) (Value, error)
+		left      Value
+		right     Value
+		expected  string
+	}{
+		{Add, date, month, "2024-02-29 00:00:00"},
+		{Add, month, date, "2024-02-29 00:00:00"},
+		{Add, timestamp, hours, "2024-03-10 14:00:00"},
+		{Subtract, timestamp, month, "2024-02-10 12:00:00"},
+		{Add, time, hours, "01:30:00"},
+		{Subtract, time, month, "23:30:00"},
+		{Add, date, NewInteger(1), "2024-02-01"},
+		{Add, NewInteger(-31), date, "2023-12-31"},
+		{Subtract, date, NewInteger(31), "2023-12-31"},
+		{Add, date, time, "2024-01-31 23:30:00"},
+		{Add, month, hours, "1 mon 02:00:00"},
+		{Subtract, later, timestamp, "2 days 06:30:00"},
+		{Subtract, timestamp, date, "39 days 12:00:00"},
+		{Subtract, NewDate(10), NewDate(3), "7"},
+		{Add, NewNull(), month, "NULL"},
+	}
+
+	for _, test := range tests {
+		result, err := test.operation(test.left, test.right)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, result.String())
+	}
+
+	negated, err := Negate(month)
+	assert.NoError(t, err)
+	assert.Equal(t, "-1 mons", negated.String())
+
+	_, err = Add(timestamp, timestamp)
+	assert.EqualError(t, err, "Cannot apply '+' to TIMESTAMP and TIMESTAMP")
+
+	_, err = Add(hours, NewText("1"))
+	assert.EqualError(t, err, "Cannot apply '+' to INTERVAL and TEXT")
+
+	_, err = Subtract(month, timestamp)
+	assert.EqualError(t, err, "Cannot apply '-' to INTERVAL and TIMESTAMP")
+}
@@ -0,0 +1,108 @@
+package types
+
+import "fmt"
+
+type Comparison uint8
+
+const (
+	COMPARISON_EQUAL Comparison = iota
+	COMPARISON_NOT_EQUAL
+	COMPARISON_LESS
+	COMPARISON_LESS_EQUAL
+	COMPARISON_GREATER
+	COMPARISON_GREATER_EQUAL
+)
+
+func (comparison Comparison) Apply(left Value, right Value) (Value, error) {
+	if left.IsNull() || right.IsNull() {
+		return NewNull(), nil
+	}
+
+	result, err := Compare(left, right)
+	if err != nil {
+		return Value{}, err
+	}
+
+	switch comparison {
+	case COMPARISON_EQUAL:
+		return NewBoolean(result == 0), nil
+	case COMPARISON_NOT_EQUAL:
+		return NewBoolean(result != 0), nil
+	case COMPARISON_LESS:
+		return NewBoolean(result < 0), nil
+	case COMPARISON_LESS_EQUAL:
+		return NewBoolean(result <= 0), nil
+	case COMPARISON_GREATER:
+		return NewBoolean(result > 0), nil
+	default:
+		return NewBoolean(result >= 0), nil
+	}
+}
+
+func And(left Value, right Value) (Value, error) {
+	if err := check_logical("AND", left, right); err != nil {
+		return Value{}, err
+	}
+
+	switch {
+	case is_false(left) || is_false(right):
+		return NewBoolean(false), nil
+	case left.IsNull() || right.IsNull():
+		return NewNull(), nil
+	default:
+		return NewBoolean(true), nil
+	}
+}
+
+func Or(left Value, right Value) (Value, error) {
+	if err := check_logical("OR", left, right); err != nil {
+		return Value{}, err
+	}
+
+	switch {
+	case IsTrue(left) || IsTrue(right):
+		return NewBoolean(true), nil
+	case left.IsNull() || right.IsNull():
+		return NewNull(), nil
+	default:
+		return NewBoolean(false), nil
+	}
+}
+
+func Not(value Value) (Value, error) {
+	if err := check_logical("NOT", value, value); err != nil {
+		return Value{}, err
+	}
+
+	if value.IsNull() {
+		return value, nil
+	}
+
+	return NewBoolean(!value.Boolean()), nil
+}
+
+func IsTrue(value Value) bool {
+	return value._type == TYPE_BOOLEAN && value.Boolean()
+}
+
+func is_false(value Value) bool {
+	return value._type == TYPE_BOOLEAN && !value.Boolean()
+}
+
+func check_logical(operator string, left Value, right Value) error {
+	for _, value := range []Value{left, right} {
+		if !value.IsNull() && value._type != TYPE_BOOLEAN {
+			return fmt.Errorf("Argument of %s must be BOOLEAN, not %s", operator, value._type)
+		}
+	}
+
+	return nil
+}
+
+func OrderCompare(left Value, right Value, nulls_first bool) (int, error) {
+	if nulls_first && (left.IsNull() || right.IsNull()) {
+		return compare_integer(null_rank(right), null_rank(left)), nil
+	}
+
+	return Compare(left, right)
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicTruthTables(t *testing.T) {
+	true_, false_, null := NewBoolean(true), NewBoolean(false), NewNull()
+
+	and_cases := []struct{ left, right, expected Value }{
+		{true_, true_, true_},
+		{true_, false_, false_},
+		{true_, null, null},
+		{false_, null, false_},
+		{null, false_, false_},
+		{null, null, null},
+	}
+	for _, test := range and_cases {
+		result, err := And(test.left, test.right)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, result, test.left.String()+" AND "+test.right.String())
+	}
+
+	or_cases := []struct{ left, right, expected Value }{
+		{false_, false_, false_},
+		{true_, false_, true_},
+		{true_, null, true_},
+		{null, true_, true_},
+		{false_, null, null},
+		{null, null, null},
+	}
+	for _, test := range or_cases {
+		result, err := Or(test.left, test.right)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, result, test.left.String()+" OR "+test.right.String())
+	}
+
+	result, err := Not(null)
+	assert.NoError(t, err)
+	assert.Equal(t, null, result)
+
+	result, err = Not(true_)
+	assert.NoError(t, err)
+	assert.Equal(t, false_, result)
+
+	_, err = And(true_, NewInteger(1))
+	assert.EqualError(t, err, "Argument of AND must be BOOLEAN, not INTEGER")
+
+	_, err = Not(NewText("t"))
+	assert.EqualError(t, err, "Argument of NOT must be BOOLEAN, not TEXT")
+}
+
+func TestComparisonNull(t *testing.T) {
+	for _, comparison := range []Comparison{COMPARISON_EQUAL, COMPARISON_NOT_EQUAL, COMPARISON_LESS, COMPARISON_GREATER_EQUAL} {
+		result, err := comparison.Apply(NewNull(), NewNull())
+		assert.NoError(t, err)
+		assert.True(t, result.IsNull())
+
+		result, err = comparison.Apply(NewInteger(1), NewNull())
+		assert.NoError(t, err)
+		assert.True(t, result.IsNull())
+	}
+
+	result, err := COMPARISON_LESS_EQUAL.Apply(NewInteger(1), NewReal(1.5))
+	assert.NoError(t, err)
+	assert.Equal(t, NewBoolean(true), result)
+
+	result, err = COMPARISON_NOT_EQUAL.Apply(NewText("a"), NewText("a"))
+	assert.NoError(t, err)
+	assert.Equal(t, NewBoolean(false), result)
+
+	_, err = COMPARISON_EQUAL.Apply(NewText("a"), NewInteger(1))
+	assert.EqualError(t, err, "Cannot compare TEXT with INTEGER")
+
+	assert.False(t, IsTrue(NewNull()))
+	assert.False(t, IsTrue(NewBoolean(false)))
+	assert.True(t, IsTrue(NewBoolean(true)))
+}
+
+func TestCompareMatchesDefaultOrder(t *testing.T) {
+	values := []Value{NewNull(), NewInteger(-1), NewInteger(0), NewText("a")}
+
+	for _, left := range values {
+		for _, right := range values {
+			expected, expected_err := OrderCompare(left, right, false)
+			result, err := Compare(left, right)
+			assert.Equal(t, expected_err, err, "%v <=> %v", left, right)
+			assert.Equal(t, expected, result, "%v <=> %v", left, right)
+		}
+	}
+
+	result, err := Compare(NewNull(), NewInteger(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+}
+
+func TestOrderCompareNulls(t *testing.T) {
+	result, err := OrderCompare(NewNull(), NewInteger(1), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+
+	result, err = OrderCompare(NewNull(), NewInteger(1), true)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, result)
+
+	result, err = OrderCompare(NewNull(), NewNull(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+
+	result, err = OrderCompare(NewInteger(2), NewInteger(1), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+}
@@ -0,0 +1,49 @@
+package types
+
+import "errors"
+
+var ErrInvalidRow = errors.New("Invalid row encoding")
+
+func EncodeRow(buffer []byte, values []Value) []byte {
+	bitmap_start := len(buffer)
+	buffer = append(buffer, make([]byte, (len(values)+7)/8)...)
+
+	for i, value := range values {
+		if value.IsNull() {
+			buffer[bitmap_start+i/8] |= 1 << (i % 8)
+			continue
+		}
+
+		buffer = Encode(buffer, value)
+	}
+
+	return buffer
+}
+
+func DecodeRow(column_types []Type, buffer []byte) ([]Value, int, error) {
+	bitmap_length := (len(column_types) + 7) / 8
+	if len(buffer) < bitmap_length {
+		return nil, 0, ErrInvalidRow
+	}
+
+	bitmap := buffer[:bitmap_length]
+	offset := bitmap_length
+	values := make([]Value, len(column_types))
+
+	for i, _type := range column_types {
+		if bitmap[i/8]&(1<<(i%8)) != 0 {
+			values[i] = NewNull()
+			continue
+		}
+
+		value, width, err := Decode(_type, buffer[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+
+		values[i] = value
+		offset += width
+	}
+
+	return values, offset, nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowRoundTrip(t *testing.T) {
+	column_types := []Type{TYPE_INTEGER, TYPE_TEXT, TYPE_BOOLEAN, TYPE_REAL, TYPE_INTEGER, TYPE_TEXT, TYPE_BLOB, TYPE_DATE, TYPE_INTEGER}
+	values := []Value{
+		NewInteger(7), NewNull(), NewBoolean(true), NewNull(), NewInteger(-1),
+		NewText("x"), NewNull(), NewDate(0), NewNull(),
+	}
+
+	buffer := EncodeRow(nil, values)
+	assert.Equal(t, []byte{0b0100_1010, 0b0000_0001}, buffer[:2])
+
+	decoded, width, err := DecodeRow(column_types, buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, len(buffer), width)
+	assert.Equal(t, values, decoded)
+}
+
+func TestRowAllNull(t *testing.T) {
+	buffer := EncodeRow(nil, []Value{NewNull(), NewNull()})
+	assert.Equal(t, []byte{0b0000_0011}, buffer)
+
+	decoded, _, err := DecodeRow([]Type{TYPE_TEXT, TYPE_DECIMAL}, buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, []Value{NewNull(), NewNull()}, decoded)
+
+	_, _, err = DecodeRow([]Type{TYPE_TEXT}, nil)
+	assert.Equal(t, ErrInvalidRow, err)
+
+	_, _, err = DecodeRow([]Type{TYPE_TEXT}, []byte{0})
+	assert.EqualError(t, err, "Invalid TEXT encoding")
+}
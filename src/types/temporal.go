@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	MICROSECONDS_PER_SECOND = 1_000_000
+	MICROSECONDS_PER_DAY    = 86_400 * MICROSECONDS_PER_SECOND
+)
+
+const (
+	DATE_LAYOUT      = "2006-01-02"
+	TIME_LAYOUT      = "15:04:05.999999"
+	TIMESTAMP_LAYOUT = "2006-01-02 15:04:05.999999"
+)
+
+var time_layouts = []string{"15:04:05", "15:04"}
+
+var timestamp_layouts = []string{
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func ParseDate(text string) (Value, error) {
+	parsed, err := time.Parse(DATE_LAYOUT, text)
+	if err != nil {
+		return Value{}, fmt.Errorf("Invalid DATE value: '%s'", text)
+	}
+
+	return NewDate(floor_divide(parsed.Unix(), 86_400)), nil
+}
+
+func ParseTime(text string) (Value, error) {
+	for _, layout := range time_layouts {
+		parsed, err := time.Parse(layout, text)
+		if err != nil {
+			continue
+		}
+
+		seconds := int64(parsed.Hour()*3600 + parsed.Minute()*60 + parsed.Second())
+		return NewTime(seconds*MICROSECONDS_PER_SECOND + int64(parsed.Nanosecond()/1000)), nil
+	}
+
+	return Value{}, fmt.Errorf("Invalid TIME value: '%s'", text)
+}
+
+func ParseTimestamp(text string) (Value, error) {
+	for _, layout := range timestamp_layouts {
+		parsed, err := time.Parse(layout, text)
+		if err != nil {
+			continue
+		}
+
+		return NewTimestamp(parsed.UnixMicro()), nil
+	}
+
+	return Value{}, fmt.Errorf("Invalid TIMESTAMP value: '%s'", text)
+}
+
+func (value Value) Time() time.Time {
+	switch value._type {
+	case TYPE_DATE:
+		return time.Unix(value.integer*86_400, 0).UTC()
+	default:
+		return time.UnixMicro(value.integer).UTC()
+	}
+}
+
+func format_temporal(value Value) string {
+	switch value._type {
+	case TYPE_DATE:
+		return value.Time().Format(DATE_LAYOUT)
+	case TYPE_TIME:
+		return value.Time().Format(TIME_LAYOUT)
+	default:
+		return value.Time().Format(TIMESTAMP_LAYOUT)
+	}
+}
+
+func floor_divide(dividend int64, divisor int64) int64 {
+	quotient := dividend / divisor
+	if dividend%divisor != 0 && (dividend < 0) != (divisor < 0) {
+		quotient -= 1
+	}
+
+	return quotient
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	value, err := ParseDate("1970-01-02")
+	assert.NoError(t, err)
+	assert.Equal(t, NewDate(1), value)
+
+	value, err = ParseDate("1969-12-31")
+	assert.NoError(t, err)
+	assert.Equal(t, NewDate(-1), value)
+
+	value, err = ParseDate("2024-02-29")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-29", value.String())
+
+	_, err = ParseDate("2023-02-29")
+	assert.EqualError(t, err, "Invalid DATE value: '2023-02-29'")
+}
+
+func TestParseTime(t *testing.T) {
+	value, err := ParseTime("13:45:30.25")
+	assert.NoError(t, err)
+	assert.Equal(t, NewTime((13*3600+45*60+30)*MICROSECONDS_PER_SECOND+250_000), value)
+	assert.Equal(t, "13:45:30.25", value.String())
+
+	value, err = ParseTime("08:15")
+	assert.NoError(t, err)
+	assert.Equal(t, "08:15:00", value.String())
+
+	_, err = ParseTime("25:00:00")
+	assert.EqualError(t, err, "Invalid TIME value: '25:00:00'")
+}
+
+func TestParseTimestamp(t *testing.T) {
+	value, err := ParseTimestamp("1970-01-01 00:00:01.000001")
+	assert.NoError(t, err)
+	assert.Equal(t, NewTimestamp(1_000_001), value)
+
+	value, err = ParseTimestamp("2024-06-01T12:00:00+02:00")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-06-01 10:00:00", value.String())
+
+	value, err = ParseTimestamp("2024-06-01")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-06-01 00:00:00", value.String())
+
+	_, err = ParseTimestamp("June 1st")
+	assert.EqualError(t, err, "Invalid TIMESTAMP value: 'June 1st'")
+}
@@ -0,0 +1,305 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Type uint8
+
+const (
+	TYPE_NULL Type = iota
+	TYPE_INTEGER
+	TYPE_REAL
+	TYPE_DECIMAL
+	TYPE_TEXT
+	TYPE_BOOLEAN
+	TYPE_BLOB
+	TYPE_DATE
+	TYPE_TIME
+	TYPE_TIMESTAMP
+	TYPE_INTERVAL
+)
+
+var type_names = [...]string{
+	TYPE_NULL:      "NULL",
+	TYPE_INTEGER:   "INTEGER",
+	TYPE_REAL:      "REAL",
+	TYPE_DECIMAL:   "DECIMAL",
+	TYPE_TEXT:      "TEXT",
+	TYPE_BOOLEAN:   "BOOLEAN",
+	TYPE_BLOB:      "BLOB",
+	TYPE_DATE:      "DATE",
+	TYPE_TIME:      "TIME",
+	TYPE_TIMESTAMP: "TIMESTAMP",
+	TYPE_INTERVAL:  "INTERVAL",
+}
+
+func (_type Type) String() string {
+	return type_names[_type]
+}
+
+func (_type Type) IsNumeric() bool {
+	return _type == TYPE_INTEGER || _type == TYPE_REAL || _type == TYPE_DECIMAL
+}
+
+func (_type Type) IsTemporal() bool {
+	return _type == TYPE_DATE || _type == TYPE_TIME || _type == TYPE_TIMESTAMP
+}
+
+type Value struct {
+	_type   Type
+	integer int64
+	real    float64
+	months  int64
+	decimal Decimal
+	bytes   string
+}
+
+func NewNull() Value {
+	return Value{}
+}
+
+func NewInteger(value int64) Value {
+	return Value{_type: TYPE_INTEGER, integer: value}
+}
+
+func NewReal(value float64) Value {
+	return Value{_type: TYPE_REAL, real: value}
+}
+
+func NewDecimal(value Decimal) Value {
+	return Value{_type: TYPE_DECIMAL, decimal: value}
+}
+
+func NewText(value string) Value {
+	return Value{_type: TYPE_TEXT, bytes: value}
+}
+
+func NewBoolean(value bool) Value {
+	if value {
+		return Value{_type: TYPE_BOOLEAN, integer: 1}
+	}
+
+	return Value{_type: TYPE_BOOLEAN}
+}
+
+func NewBlob(value []byte) Value {
+	return Value{_type: TYPE_BLOB, bytes: string(value)}
+}
+
+func NewDate(days int64) Value {
+	return Value{_type: TYPE_DATE, integer: days}
+}
+
+func NewTime(microseconds int64) Value {
+	return Value{_type: TYPE_TIME, integer: microseconds}
+}
+
+func NewTimestamp(microseconds int64) Value {
+	return Value{_type: TYPE_TIMESTAMP, integer: microseconds}
+}
+
+func NewInterval(months int64, microseconds int64) Value {
+	return Value{_type: TYPE_INTERVAL, integer: microseconds, months: months}
+}
+
+func (value Value) Type() Type {
+	return value._type
+}
+
+func (value Value) IsNull() bool {
+	return value._type == TYPE_NULL
+}
+
+func (value Value) Integer() int64 {
+	return value.integer
+}
+
+func (value Value) Real() float64 {
+	return value.real
+}
+
+func (value Value) Decimal() Decimal {
+	return value.decimal
+}
+
+func (value Value) Text() string {
+	return value.bytes
+}
+
+func (value Value) Boolean() bool {
+	return value.integer != 0
+}
+
+func (value Value) Blob() []byte {
+	return []byte(value.bytes)
+}
+
+func (value Value) Months() int64 {
+	return value.months
+}
+
+func (value Value) String() string {
+	switch value._type {
+	case TYPE_NULL:
+		return "NULL"
+	case TYPE_INTEGER:
+		return strconv.FormatInt(value.integer, 10)
+	case TYPE_REAL:
+		return strconv.FormatFloat(value.real, 'g', -1, 64)
+	case TYPE_DECIMAL:
+		return value.decimal.String()
+	case TYPE_BOOLEAN:
+		if value.Boolean() {
+			return "TRUE"
+		}
+		return "FALSE"
+	case TYPE_BLOB:
+		return "X'" + strings.ToUpper(hex.EncodeToString([]byte(value.bytes))) + "'"
+	case TYPE_DATE, TYPE_TIME, TYPE_TIMESTAMP:
+		return format_temporal(value)
+	case TYPE_INTERVAL:
+		return format_interval(value)
+	default:
+		return value.bytes
+	}
+}
+
+func ParseInteger(text string) (Value, error) {
+	digits := text
+	sign := ""
+	base := 10
+
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	if len(digits) > 1 && digits[0] == '0' && (digits[1] == 'x' || digits[1] == 'X') {
+		digits = digits[2:]
+		base = 16
+	}
+
+	value, err := strconv.ParseInt(sign+digits, base, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return Value{}, fmt.Errorf("INTEGER value out of range: %s", text)
+	}
+	if err != nil {
+		return Value{}, fmt.Errorf("Invalid INTEGER value: '%s'", text)
+	}
+
+	return NewInteger(value), nil
+}
+
+func ParseReal(text string) (Value, error) {
+	value, err := strconv.ParseFloat(text, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return Value{}, fmt.Errorf("REAL value out of range: %s", text)
+	}
+	if err != nil {
+		return Value{}, fmt.Errorf("Invalid REAL value: '%s'", text)
+	}
+
+	return NewReal(value), nil
+}
+
+func ParseBlob(text string) (Value, error) {
+	value, err := hex.DecodeString(text)
+	if err != nil {
+		return Value{}, fmt.Errorf("Invalid BLOB value: '%s'", text)
+	}
+
+	return NewBlob(value), nil
+}
+
+func Compare(left Value, right Value) (int, error) {
+	switch {
+	case left.IsNull() || right.IsNull():
+		return compare_integer(null_rank(left), null_rank(right)), nil
+	case left._type.IsNumeric() && right._type.IsNumeric():
+		return compare_numeric(left, right), nil
+	case left._type == TYPE_DATE && right._type == TYPE_TIMESTAMP:
+		return compare_integer(left.integer*MICROSECONDS_PER_DAY, right.integer), nil
+	case left._type == TYPE_TIMESTAMP && right._type == TYPE_DATE:
+		return compare_integer(left.integer, right.integer*MICROSECONDS_PER_DAY), nil
+	case left._type == TYPE_INTERVAL && right._type == TYPE_INTERVAL:
+		return interval_span(left).Cmp(interval_span(right)), nil
+	case left._type != right._type:
+		return 0, fmt.Errorf("Cannot compare %s with %s", left._type, right._type)
+	case left._type == TYPE_TEXT || left._type == TYPE_BLOB:
+		return strings.Compare(left.bytes, right.bytes), nil
+	default:
+		return compare_integer(left.integer, right.integer), nil
+	}
+}
+
+func compare_integer(left int64, right int64) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compare_numeric(left Value, right Value) int {
+	if left._type == TYPE_INTEGER && right._type == TYPE_INTEGER {
+		return compare_integer(left.integer, right.integer)
+	}
+
+	if left._type == TYPE_REAL || right._type == TYPE_REAL {
+		left_real, right_real := to_real(left), to_real(right)
+
+		if math.IsNaN(left_real) || math.IsNaN(right_real) {
+			return compare_integer(nan_rank(left_real), nan_rank(right_real))
+		}
+
+		return compare_real(left_real, right_real)
+	}
+
+	return to_decimal(left).Compare(to_decimal(right))
+}
+
+func compare_real(left float64, right float64) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func null_rank(value Value) int64 {
+	if value.IsNull() {
+		return 1
+	}
+
+	return 0
+}
+
+func nan_rank(value float64) int64 {
+	if math.IsNaN(value) {
+		return 1
+	}
+
+	return 0
+}
+
+func to_real(value Value) float64 {
+	switch value._type {
+	case TYPE_INTEGER:
+		return float64(value.integer)
+	case TYPE_DECIMAL:
+		return value.decimal.Float64()
+	default:
+		return value.real
+	}
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInteger(t *testing.T) {
+	cases := map[string]int64{
+		"0":                    0,
+		"06":                   6,
+		"0x1F":                 31,
+		"0XfF":                 255,
+		"-42":                  -42,
+		"9223372036854775807":  math.MaxInt64,
+		"-9223372036854775808": math.MinInt64,
+		"-0x8000000000000000":  math.MinInt64,
+	}
+
+	for text, expected := range cases {
+		value, err := ParseInteger(text)
+		assert.NoError(t, err, text)
+		assert.Equal(t, NewInteger(expected), value, text)
+	}
+}
+
+func TestParseIntegerErrors(t *testing.T) {
+	_, err := ParseInteger("9223372036854775808")
+	assert.EqualError(t, err, "INTEGER value out of range: 9223372036854775808")
+
+	_, err = ParseInteger("0x8000000000000000")
+	assert.EqualError(t, err, "INTEGER value out of range: 0x8000000000000000")
+
+	_, err = ParseInteger("1.5")
+	assert.EqualError(t, err, "Invalid INTEGER value: '1.5'")
+}
+
+func TestParseReal(t *testing.T) {
+	value, err := ParseReal("1.5e-3")
+	assert.NoError(t, err)
+	assert.Equal(t, NewReal(0.0015), value)
+
+	_, err = ParseReal("1e400")
+	assert.EqualError(t, err, "REAL value out of range: 1e400")
+}
+
+func TestParseBlob(t *testing.T) {
+	value, err := ParseBlob("00fFa1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0xff, 0xa1}, value.Blob())
+	assert.Equal(t, "X'00FFA1'", value.String())
+
+	_, err = ParseBlob("abc")
+	assert.EqualError(t, err, "Invalid BLOB value: 'abc'")
+}
+
+func TestValueString(t *testing.T) {
+	assert.Equal(t, "-7", NewInteger(-7).String())
+	assert.Equal(t, "2.5", NewReal(2.5).String())
+	assert.Equal(t, "12.340", NewDecimal(MakeDecimal(12340, 3)).String())
+	assert.Equal(t, "hello", NewText("hello").String())
+	assert.Equal(t, "TRUE", NewBoolean(true).String())
+	assert.Equal(t, "FALSE", NewBoolean(false).String())
+}
+
+func TestCompareNumeric(t *testing.T) {
+	cases := []struct {
+		left     Value
+		right    Value
+		expected int
+	}{
+		{NewInteger(1), NewInteger(2), -1},
+		{NewInteger(2), NewReal(1.5), 1},
+		{NewReal(2), NewInteger(2), 0},
+		{NewDecimal(MakeDecimal(150, 2)), NewReal(1.5), 0},
+		{NewDecimal(MakeDecimal(1, 1)), NewReal(0.1), 0},
+		{NewInteger(math.MaxInt64 - 1), NewInteger(math.MaxInt64), -1},
+		{NewInteger(3), NewDecimal(MakeDecimal(30001, 4)), -1},
+		{NewReal(math.Inf(1)), NewInteger(math.MaxInt64), 1},
+		{NewReal(math.NaN()), NewReal(math.Inf(1)), 1},
+	}
+
+	for _, c := range cases {
+		result, err := Compare(c.left, c.right)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, result, "%v <=> %v", c.left, c.right)
+	}
+}
+
+func TestCompareNonNumeric(t *testing.T) {
+	date, _ := ParseDate("2024-03-01")
+	timestamp, _ := ParseTimestamp("2024-03-01 00:00:01")
+
+	result, err := Compare(NewText("abc"), NewText("abd"))
+	assert.NoError(t, err)
+	assert.Equal(t, -1, result)
+
+	result, err = Compare(NewBoolean(true), NewBoolean(false))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result)
+
+	result, err = Compare(NewBlob([]byte{1, 2}), NewBlob([]byte{1, 2}))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+
+	result, err = Compare(date, timestamp)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, result)
+
+	_, err = Compare(NewText("1"), NewInteger(1))
+	assert.EqualError(t, err, "Cannot compare TEXT with INTEGER")
+
+	_, err = Compare(date, NewTime(0))
+	assert.EqualError(t, err, "Cannot compare DATE with TIME")
+}